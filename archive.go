@@ -0,0 +1,190 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+// defaultArchivePattern is used for searching dbf file in archive when pattern is empty.
+const defaultArchivePattern = "*.dbf"
+
+// NewTerReaderFromZip is a TerReader constructor for dbf file packed in zip archive.
+// File is searched by pattern which is matched against base name of archive entries case-insensitively,
+// empty pattern means "*.dbf". Exactly one entry must match the pattern.
+// Companion memo files (.dbt, .fpt) are not used because go-dbf does not support memo fields.
+func NewTerReaderFromZip(filePath, pattern, encoding string) (*TerReader, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var names []string
+	var matched []*zip.File
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		names = append(names, f.Name)
+
+		ok, err := matchArchiveEntry(pattern, f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, f)
+		}
+	}
+
+	if len(matched) != 1 {
+		return nil, archiveEntryError(filePath, pattern, names, len(matched))
+	}
+
+	rc, err := matched[0].Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTerReaderFromByteSlice(data, encoding)
+}
+
+// NewTerReaderFromGzip is a TerReader constructor for dbf file compressed by gzip.
+// If gzip header stores original file name, it must have .dbf extension.
+func NewTerReaderFromGzip(filePath, encoding string) (*TerReader, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	if gr.Name != "" {
+		ok, err := matchArchiveEntry("", gr.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, archiveEntryError(filePath, "", []string{gr.Name}, 0)
+		}
+	}
+
+	data, err := ioutil.ReadAll(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTerReaderFromByteSlice(data, encoding)
+}
+
+// NewTerReaderFromTarGz is a TerReader constructor for dbf file packed in tar archive compressed by gzip.
+// File is searched by pattern like in NewTerReaderFromZip.
+func NewTerReaderFromTarGz(filePath, pattern, encoding string) (*TerReader, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	var names []string
+	var matched int
+	var data []byte
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		names = append(names, header.Name)
+
+		ok, err := matchArchiveEntry(pattern, header.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		matched++
+		if matched == 1 {
+			if data, err = ioutil.ReadAll(tr); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if matched != 1 {
+		return nil, archiveEntryError(filePath, pattern, names, matched)
+	}
+
+	return NewTerReaderFromByteSlice(data, encoding)
+}
+
+func matchArchiveEntry(pattern, name string) (bool, error) {
+	if pattern == "" {
+		pattern = defaultArchivePattern
+	}
+
+	return path.Match(strings.ToLower(pattern), strings.ToLower(path.Base(name)))
+}
+
+func archiveEntryError(filePath, pattern string, names []string, matched int) error {
+	if pattern == "" {
+		pattern = defaultArchivePattern
+	}
+
+	found := strings.Join(names, ", ")
+	if found == "" {
+		found = "nothing"
+	}
+
+	if matched == 0 {
+		return fmt.Errorf("no file matching '%s' in archive '%s', found: %s", pattern, filePath, found)
+	}
+
+	return fmt.Errorf("%d files matching '%s' in archive '%s', found: %s", matched, pattern, filePath, found)
+}
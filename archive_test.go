@@ -0,0 +1,215 @@
+package terreader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type archiveEntry struct {
+	name string
+	data []byte
+}
+
+func readTestFile(t *testing.T) []byte {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func writeZip(t *testing.T, entries ...archiveEntry) string {
+	path := filepath.Join(t.TempDir(), "list.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, entry := range entries {
+		w, err := zw.Create(entry.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(entry.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func writeTarGz(t *testing.T, entries ...archiveEntry) string {
+	path := filepath.Join(t.TempDir(), "list.tar.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0o600, Size: int64(len(entry.data)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(entry.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func writeGzip(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), "list.dbf.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	gw.Name = name
+	if _, err := gw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func assertReaderHasFirstRecord(t *testing.T, tr *TerReader, err error) {
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := tr.Read(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := <-results
+	if res.Error != nil {
+		t.Fatal(res.Error)
+	}
+	if res.Row.Nameu != "Pharetra magna ac placerat" {
+		t.Errorf("get not correct Row. Got %+v", *res.Row)
+	}
+}
+
+func assertArchiveError(t *testing.T, tr *TerReader, err error, etalon string) {
+	if err == nil {
+		t.Errorf("error object not correct. Expected \"%s\", got nil", etalon)
+	} else if err.Error() != etalon {
+		t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", etalon, err.Error())
+	}
+	if tr != nil {
+		t.Errorf("get not correct TerReader. Expected nil, got %+v", tr)
+	}
+}
+
+func TestNewTerReaderFromZip(t *testing.T) {
+	data := readTestFile(t)
+
+	t.Run("when one file matches", func(t *testing.T) {
+		path := writeZip(t, archiveEntry{"readme.txt", []byte("readme")}, archiveEntry{"data/TER.DBF", data}, archiveEntry{"data/TER.DBT", nil})
+		tr, err := NewTerReaderFromZip(path, "", fileEncoding)
+		assertReaderHasFirstRecord(t, tr, err)
+	})
+
+	t.Run("when pattern is provided", func(t *testing.T) {
+		path := writeZip(t, archiveEntry{"old.dbf", []byte("old")}, archiveEntry{"ter_new.dbf", data})
+		tr, err := NewTerReaderFromZip(path, "ter_*.dbf", fileEncoding)
+		assertReaderHasFirstRecord(t, tr, err)
+	})
+
+	t.Run("when nothing matches", func(t *testing.T) {
+		path := writeZip(t, archiveEntry{"readme.txt", []byte("readme")})
+		tr, err := NewTerReaderFromZip(path, "", fileEncoding)
+		assertArchiveError(t, tr, err, "no file matching '*.dbf' in archive '"+path+"', found: readme.txt")
+	})
+
+	t.Run("when multiple files match", func(t *testing.T) {
+		path := writeZip(t, archiveEntry{"a.dbf", data}, archiveEntry{"b.dbf", data})
+		tr, err := NewTerReaderFromZip(path, "", fileEncoding)
+		assertArchiveError(t, tr, err, "2 files matching '*.dbf' in archive '"+path+"', found: a.dbf, b.dbf")
+	})
+
+	t.Run("when pattern is not correct", func(t *testing.T) {
+		path := writeZip(t, archiveEntry{"a.dbf", data})
+		tr, err := NewTerReaderFromZip(path, "[", fileEncoding)
+		assertArchiveError(t, tr, err, "syntax error in pattern")
+	})
+
+	t.Run("when archive not exists", func(t *testing.T) {
+		tr, err := NewTerReaderFromZip("not/exists/file.zip", "", fileEncoding)
+		assertArchiveError(t, tr, err, "open not/exists/file.zip: no such file or directory")
+	})
+}
+
+func TestNewTerReaderFromGzip(t *testing.T) {
+	data := readTestFile(t)
+
+	t.Run("when name is stored", func(t *testing.T) {
+		tr, err := NewTerReaderFromGzip(writeGzip(t, "TER.DBF", data), fileEncoding)
+		assertReaderHasFirstRecord(t, tr, err)
+	})
+
+	t.Run("when name is not stored", func(t *testing.T) {
+		tr, err := NewTerReaderFromGzip(writeGzip(t, "", data), fileEncoding)
+		assertReaderHasFirstRecord(t, tr, err)
+	})
+
+	t.Run("when name is not dbf", func(t *testing.T) {
+		path := writeGzip(t, "ter.xml", data)
+		tr, err := NewTerReaderFromGzip(path, fileEncoding)
+		assertArchiveError(t, tr, err, "no file matching '*.dbf' in archive '"+path+"', found: ter.xml")
+	})
+
+	t.Run("when file is not gzip", func(t *testing.T) {
+		tr, err := NewTerReaderFromGzip(filePath, fileEncoding)
+		assertArchiveError(t, tr, err, "gzip: invalid header")
+	})
+}
+
+func TestNewTerReaderFromTarGz(t *testing.T) {
+	data := readTestFile(t)
+
+	t.Run("when one file matches", func(t *testing.T) {
+		path := writeTarGz(t, archiveEntry{"list/readme.txt", []byte("readme")}, archiveEntry{"list/ter.dbf", data})
+		tr, err := NewTerReaderFromTarGz(path, "", fileEncoding)
+		assertReaderHasFirstRecord(t, tr, err)
+	})
+
+	t.Run("when nothing matches", func(t *testing.T) {
+		path := writeTarGz(t)
+		tr, err := NewTerReaderFromTarGz(path, "ter.dbf", fileEncoding)
+		assertArchiveError(t, tr, err, "no file matching 'ter.dbf' in archive '"+path+"', found: nothing")
+	})
+
+	t.Run("when multiple files match", func(t *testing.T) {
+		path := writeTarGz(t, archiveEntry{"a.dbf", data}, archiveEntry{"b.DBF", data})
+		tr, err := NewTerReaderFromTarGz(path, "", fileEncoding)
+		assertArchiveError(t, tr, err, "2 files matching '*.dbf' in archive '"+path+"', found: a.dbf, b.DBF")
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Batch structure for store records which are delivered together by ReadBatches.
+type Batch struct {
+	Rows []Row
+	// Numbers are numbers of records in Rows.
+	Numbers []uint64
+	// Err joins errors of results of the batch, it is nil if all records were read.
+	Err error
+}
+
+// ReadBatches reads records by ReadContext and delivers them in batches of provided size, the last batch
+// can be smaller. Records are delivered in order of numbers. If reading is stopped, the last batch contains
+// the records which were read before and the error. It is always sent, and if the consumer has not received
+// the previous batch yet, the last batch takes its place and records.
+func (tr *TerReader) ReadBatches(ctx context.Context, size int, opts ...ReadOption) (chan Batch, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("batch size '%d' is not correct", size)
+	}
+
+	results, err := tr.ReadContext(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tr.mu.Lock()
+	done := tr.doneChan()
+	tr.mu.Unlock()
+
+	// Buffer keeps place for the last batch, so it can be sent without blocking when reading is stopped.
+	batchChan := make(chan Batch, 1)
+	go func() {
+		defer close(batchChan)
+
+		stopped := false
+		send := func(batch Batch) bool {
+			select {
+			case batchChan <- batch:
+				return true
+			case <-ctx.Done():
+			case <-done:
+			}
+			stopped = true
+
+			return false
+		}
+
+		batch := Batch{}
+		var errs []error
+		for res := range results {
+			if res.Error != nil {
+				if res.Number != 0 {
+					res.Error = fmt.Errorf("error for record with number '%d': %w", res.Number, res.Error)
+				}
+				errs = append(errs, res.Error)
+				continue
+			}
+			if stopped {
+				// Records after the not sent batch are not delivered, the last batch contains only the error.
+				continue
+			}
+
+			batch.Rows = append(batch.Rows, *res.Row)
+			batch.Numbers = append(batch.Numbers, res.Number)
+			if len(batch.Rows) == size {
+				if !send(batch) {
+					continue
+				}
+				batch = Batch{}
+			}
+		}
+
+		if len(batch.Rows) == 0 && len(errs) == 0 {
+			return
+		}
+		if stopped && len(errs) == 0 {
+			// Reading of results is finished before the batch is sent.
+			errs = append(errs, stopErr(ctx, done))
+		}
+		batch.Err = errors.Join(errs...)
+		if !stopped && send(batch) {
+			return
+		}
+
+		// Consumer can stop reading when reading is stopped, so the last batch is sent without blocking.
+		// If the previous batch is not received yet, the last batch takes its place and records.
+		select {
+		case batchChan <- batch:
+		case prev := <-batchChan:
+			prev.Err = batch.Err
+			batchChan <- prev
+		}
+	}()
+
+	return batchChan, nil
+}
+
+// UpdateBatch moves checkpoint after records of the batch. Batch with error is ignored,
+// so its records are read again after resuming.
+func (c *Checkpoint) UpdateBatch(batch Batch) {
+	if batch.Err != nil || len(batch.Numbers) == 0 {
+		return
+	}
+
+	c.Update(RowReadResult{Row: &batch.Rows[len(batch.Rows)-1], Number: batch.Numbers[len(batch.Numbers)-1]})
+}
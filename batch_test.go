@@ -0,0 +1,155 @@
+package terreader
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTerReader_ReadBatches(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batches, err := tr.ReadBatches(context.Background(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []Row
+	var numbers [][]uint64
+	var cp Checkpoint
+	for batch := range batches {
+		if batch.Err != nil {
+			t.Fatal(batch.Err)
+		}
+		rows = append(rows, batch.Rows...)
+		numbers = append(numbers, batch.Numbers)
+		cp.UpdateBatch(batch)
+	}
+
+	etalonNumbers := [][]uint64{{1, 2, 3, 4}, {5, 6}}
+	if !reflect.DeepEqual(numbers, etalonNumbers) {
+		t.Errorf("numbers not correct. Expected %v, got %v", etalonNumbers, numbers)
+	}
+
+	var etalonRows []Row
+	for _, res := range getSuccessEtalonRecords() {
+		etalonRows = append(etalonRows, *res.Row)
+	}
+	if !reflect.DeepEqual(rows, etalonRows) {
+		t.Errorf("rows not correct. Expected %+v, got %+v", etalonRows, rows)
+	}
+
+	etalonCheckpoint := Checkpoint{Next: 7}
+	if cp != etalonCheckpoint {
+		t.Errorf("checkpoint not correct. Expected %+v, got %+v", etalonCheckpoint, cp)
+	}
+}
+
+func TestTerReader_ReadBatches_WithError(t *testing.T) {
+	rows := getSuccessTestRows()
+	for i, row := range conflictingRows() {
+		row["NUMBER"] = "7"
+		row["ROW_ID"] = strconv.Itoa(101 + i)
+		rows = append(rows, row)
+	}
+
+	tr, err := NewTerReaderFromTable(NewMapTable(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batches, err := tr.ReadBatches(context.Background(), 4, WithConflictPolicy(ConflictError))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var all []Batch
+	for batch := range batches {
+		all = append(all, batch)
+	}
+
+	if len(all) != 2 {
+		t.Fatalf("number of batches not correct. Expected 2, got %d", len(all))
+	}
+	etalonNumbers := []uint64{5, 6}
+	if !reflect.DeepEqual(all[1].Numbers, etalonNumbers) {
+		t.Errorf("numbers not correct. Expected %v, got %v", etalonNumbers, all[1].Numbers)
+	}
+
+	etalonError := errors.New("error for record with number '7': field 'TU' has conflicting values '1' and '2'")
+	if all[1].Err == nil {
+		t.Fatalf("error object not correct. Expected %v, got nil", etalonError)
+	}
+	if all[1].Err.Error() != etalonError.Error() {
+		t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", etalonError.Error(), all[1].Err.Error())
+	}
+	var recordErr *RecordError
+	if !errors.As(all[1].Err, &recordErr) || recordErr.Kind != ErrorKindConflict {
+		t.Errorf("error object not correct. Expected *RecordError of kind %s, got %#v", ErrorKindConflict, all[1].Err)
+	}
+
+	var cp Checkpoint
+	for _, batch := range all {
+		cp.UpdateBatch(batch)
+	}
+	etalonCheckpoint := Checkpoint{Next: 5}
+	if cp != etalonCheckpoint {
+		t.Errorf("checkpoint not correct. Expected %+v, got %+v", etalonCheckpoint, cp)
+	}
+}
+
+func TestTerReader_ReadBatches_WithCanceledContext(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	batches, err := tr.ReadBatches(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-batches
+	for len(batches) < cap(batches) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	var last Batch
+	next := uint64(3)
+	for batch := range batches {
+		for _, number := range batch.Numbers {
+			if number != next {
+				t.Errorf("number not correct. Expected %d, got %d", next, number)
+			}
+			next++
+		}
+		last = batch
+	}
+	// Error is not sent if all records are delivered before reading is stopped.
+	if (last.Err != nil || next != 7) && !errors.Is(last.Err, context.Canceled) {
+		t.Errorf("error object not correct. Expected %v, got %v", context.Canceled, last.Err)
+	}
+}
+
+func TestTerReader_ReadBatches_WithWrongSize(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tr.ReadBatches(context.Background(), 0)
+	etalonError := errors.New("batch size '0' is not correct")
+	if err == nil {
+		t.Errorf("error object not correct. Expected %v, got nil", etalonError)
+	} else if err.Error() != etalonError.Error() {
+		t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", etalonError.Error(), err.Error())
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Checkpoint structure for store position of reading. Consumer can persist it after processing of records
+// and resume reading by ReadFrom with Next after restart. Zero Checkpoint means reading from the first record.
+type Checkpoint struct {
+	// Next is a number of record from which reading is resumed.
+	Next uint64
+}
+
+// Update moves checkpoint after record of the result. Results with errors are ignored,
+// so the record is read again after resuming.
+func (c *Checkpoint) Update(res RowReadResult) {
+	if res.Error != nil || res.Row == nil || res.Number == math.MaxUint64 {
+		return
+	}
+
+	c.Next = res.Number + 1
+}
+
+// WithRange limits reading by records with numbers from from to to inclusive. Records are read in order
+// of numbers, so the range can be used for resuming of reading.
+func WithRange(from, to uint64) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.limited = true
+		cfg.from = from
+		cfg.to = to
+	}
+}
+
+// ReadFrom reads records with numbers which are greater than or equal to provided one, see ReadContext.
+func (tr *TerReader) ReadFrom(ctx context.Context, number uint64, opts ...ReadOption) (chan RowReadResult, error) {
+	return tr.ReadRange(ctx, number, math.MaxUint64, opts...)
+}
+
+// ReadRange reads records with numbers from from to to inclusive, see ReadContext.
+func (tr *TerReader) ReadRange(ctx context.Context, from, to uint64, opts ...ReadOption) (chan RowReadResult, error) {
+	return tr.ReadContext(ctx, append(opts[:len(opts):len(opts)], WithRange(from, to))...)
+}
+
+// validateSelection checks range and shard of the config.
+func (cfg *readConfig) validateSelection() error {
+	if cfg.limited && cfg.from > cfg.to {
+		return fmt.Errorf("range from '%d' to '%d' is not correct", cfg.from, cfg.to)
+	}
+	if cfg.sharded && (cfg.shardTotal <= 0 || cfg.shardIndex < 0 || cfg.shardIndex >= cfg.shardTotal) {
+		return fmt.Errorf("shard '%d' of '%d' is not correct", cfg.shardIndex, cfg.shardTotal)
+	}
+
+	return nil
+}
+
+// selectNumbers returns numbers of records which are read with the config. Numbers must be sorted.
+// Shard is selected from all numbers, then the range is applied to it.
+func (cfg *readConfig) selectNumbers(numbers []uint64) []uint64 {
+	if cfg.sharded {
+		numbers = shardNumbers(numbers, cfg.shardIndex, cfg.shardTotal)
+	}
+	if !cfg.limited {
+		return numbers
+	}
+
+	start := sort.Search(len(numbers), func(i int) bool {
+		return numbers[i] >= cfg.from
+	})
+	end := sort.Search(len(numbers), func(i int) bool {
+		return numbers[i] > cfg.to
+	})
+
+	return numbers[start:end]
+}
@@ -0,0 +1,116 @@
+package terreader
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func readNumbers(t *testing.T, results chan RowReadResult) []uint64 {
+	var numbers []uint64
+	for res := range results {
+		if res.Error != nil {
+			t.Fatal(res.Error)
+		}
+		numbers = append(numbers, res.Number)
+	}
+
+	return numbers
+}
+
+func TestTerReader_ReadRange(t *testing.T) {
+	testCases := []struct {
+		title   string
+		from    uint64
+		to      uint64
+		numbers []uint64
+	}{
+		{"when range is inside", 2, 4, []uint64{2, 3, 4}},
+		{"when range is one record", 5, 5, []uint64{5}},
+		{"when range includes all records", 0, 100, []uint64{1, 2, 3, 4, 5, 6}},
+		{"when range is after all records", 7, 10, nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := tr.ReadRange(context.Background(), testCase.from, testCase.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			numbers := readNumbers(t, results)
+			if !reflect.DeepEqual(numbers, testCase.numbers) {
+				t.Errorf("numbers not correct. Expected %v, got %v", testCase.numbers, numbers)
+			}
+		})
+	}
+
+	t.Run("when range is not correct", func(t *testing.T) {
+		tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tr.ReadRange(context.Background(), 4, 2)
+		etalonError := errors.New("range from '4' to '2' is not correct")
+		if err == nil {
+			t.Errorf("error object not correct. Expected %v, got nil", etalonError)
+		} else if err.Error() != etalonError.Error() {
+			t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", etalonError.Error(), err.Error())
+		}
+	})
+}
+
+func TestTerReader_ReadFrom(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cp Checkpoint
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := tr.ReadFrom(ctx, cp.Next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for res := range results {
+		if res.Number == 3 {
+			// Load of the record is failed, so it is not checkpointed.
+			cancel()
+			break
+		}
+		cp.Update(res)
+	}
+
+	etalon := Checkpoint{Next: 3}
+	if cp != etalon {
+		t.Errorf("checkpoint not correct. Expected %+v, got %+v", etalon, cp)
+	}
+
+	results, err = tr.ReadFrom(context.Background(), cp.Next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	etalonNumbers := []uint64{3, 4, 5, 6}
+	if numbers := readNumbers(t, results); !reflect.DeepEqual(numbers, etalonNumbers) {
+		t.Errorf("numbers not correct. Expected %v, got %v", etalonNumbers, numbers)
+	}
+}
+
+func TestCheckpoint_Update(t *testing.T) {
+	var cp Checkpoint
+	cp.Update(RowReadResult{Row: &Row{}, Number: 4})
+	cp.Update(RowReadResult{Number: 5, Error: errors.New("build error")})
+
+	etalon := Checkpoint{Next: 5}
+	if cp != etalon {
+		t.Errorf("checkpoint not correct. Expected %+v, got %+v", etalon, cp)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command terreader provides tools for working with terrorists database.
+//
+// Usage:
+//
+//	terreader serve -file /path/to/file.dbf [-encoding 866] [-addr :8080]
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+const usage = `usage: terreader <command> [arguments]
+
+commands:
+  serve    start HTTP screening service`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("command is not provided\n%s", usage)
+	}
+
+	switch args[0] {
+	case "serve":
+		return serve(args[1:])
+	}
+
+	return fmt.Errorf("unknown command '%s'\n%s", args[0], usage)
+}
@@ -0,0 +1,195 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/will-evil/terreader"
+	"github.com/will-evil/terreader/screen"
+)
+
+const (
+	birthDateFormat   = "2006-01-02"
+	recordsPathPrefix = "/records/"
+	// maxScreenBodySize is a limit of size of body of screening request.
+	maxScreenBodySize = 1 << 20
+)
+
+type loadFunc func(path string) (*screen.Index, error)
+
+// server structure that provides HTTP API for screening. Index is replaced atomically on reload,
+// requests which are in progress keep working with the previous one.
+type server struct {
+	path     string
+	load     loadFunc
+	index    atomic.Value
+	reloadMu sync.Mutex
+}
+
+type screenRequest struct {
+	Name      string `json:"name"`
+	BirthDate string `json:"birth_date"`
+	Document  string `json:"document"`
+	Limit     int    `json:"limit"`
+}
+
+type screenHit struct {
+	Number  string        `json:"number"`
+	Score   float64       `json:"score"`
+	Matched []string      `json:"matched"`
+	Record  terreader.Row `json:"record"`
+}
+
+type screenResponse struct {
+	Hits []screenHit `json:"hits"`
+}
+
+type reloadResponse struct {
+	Records int `json:"records"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func newServer(path string, load loadFunc) (*server, error) {
+	s := &server{path: path, load: load}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *server) currentIndex() *screen.Index {
+	return s.index.Load().(*screen.Index)
+}
+
+func (s *server) reload() error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	idx, err := s.load(s.path)
+	if err != nil {
+		return err
+	}
+	s.index.Store(idx)
+
+	return nil
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(recordsPathPrefix, s.handleRecord)
+	mux.HandleFunc("/screen", s.handleScreen)
+	mux.HandleFunc("/reload", s.handleReload)
+
+	return mux
+}
+
+func (s *server) handleRecord(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method '%s' not allowed", r.Method))
+		return
+	}
+
+	number := strings.TrimPrefix(r.URL.Path, recordsPathPrefix)
+	if number == "" || strings.Contains(number, "/") {
+		writeError(w, http.StatusNotFound, fmt.Errorf("path '%s' not found", r.URL.Path))
+		return
+	}
+
+	row, ok := s.currentIndex().Get(number)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("record with number '%s' not found", number))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, row)
+}
+
+func (s *server) handleScreen(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method '%s' not allowed", r.Method))
+		return
+	}
+
+	var req screenRequest
+	body := http.MaxBytesReader(w, r.Body, maxScreenBodySize)
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	query := screen.Query{Name: req.Name, Document: req.Document}
+	if req.BirthDate != "" {
+		birthDate, err := time.Parse(birthDateFormat, req.BirthDate)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		query.BirthDate = &birthDate
+	}
+
+	hits := s.currentIndex().Screen(query, req.Limit)
+	resp := screenResponse{Hits: make([]screenHit, 0, len(hits))}
+	for _, hit := range hits {
+		resp.Hits = append(resp.Hits, screenHit{
+			Number:  hit.Row.Number,
+			Score:   hit.Score,
+			Matched: hit.Matched,
+			Record:  hit.Row,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *server) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method '%s' not allowed", r.Method))
+		return
+	}
+
+	if err := s.reload(); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reloadResponse{Records: s.currentIndex().Len()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
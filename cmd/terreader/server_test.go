@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/will-evil/terreader"
+	"github.com/will-evil/terreader/screen"
+)
+
+const fileEncoding = "866"
+const filePath = "../../test/data/testfile.dbf"
+
+func newTestServer(t *testing.T) *server {
+	srv, err := newServer(filePath, func(path string) (*screen.Index, error) {
+		tr, err := terreader.NewTerReader(path, fileEncoding)
+		if err != nil {
+			return nil, err
+		}
+		return screen.Load(tr)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return srv
+}
+
+func doRequest(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+	return rec
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		args []string
+		err  string
+	}{
+		{nil, "command is not provided"},
+		{[]string{"unknown"}, "unknown command 'unknown'"},
+		{[]string{"serve"}, "flag -file is required"},
+		{[]string{"serve", "-file", "not/exists/file.dbf"}, "open not/exists/file.dbf: no such file or directory"},
+	}
+
+	for _, testCase := range testCases {
+		err := run(testCase.args)
+		if err == nil {
+			t.Errorf("error object not correct. Expected \"%s\", got nil", testCase.err)
+		} else if !strings.HasPrefix(err.Error(), testCase.err) {
+			t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err, err.Error())
+		}
+	}
+}
+
+func TestServer_handleRecord(t *testing.T) {
+	h := newTestServer(t).handler()
+
+	testCases := []struct {
+		method string
+		target string
+		status int
+	}{
+		{http.MethodGet, "/records/1", http.StatusOK},
+		{http.MethodGet, "/records/2", http.StatusNotFound},
+		{http.MethodGet, "/records/", http.StatusNotFound},
+		{http.MethodPost, "/records/1", http.StatusMethodNotAllowed},
+	}
+
+	for _, testCase := range testCases {
+		rec := doRequest(h, testCase.method, testCase.target, "")
+		if rec.Code != testCase.status {
+			t.Errorf("%s %s: status not correct. Expected %d, got %d", testCase.method, testCase.target, testCase.status, rec.Code)
+		}
+	}
+
+	var row terreader.Row
+	rec := doRequest(h, http.MethodGet, "/records/1", "")
+	if err := json.NewDecoder(rec.Body).Decode(&row); err != nil {
+		t.Fatal(err)
+	}
+	if row.Number != "1" || row.Nameu != "Pharetra magna ac placerat" {
+		t.Errorf("get not correct Row. Got %+v", row)
+	}
+}
+
+func TestServer_handleScreen(t *testing.T) {
+	h := newTestServer(t).handler()
+
+	t.Run("when hits found", func(t *testing.T) {
+		body := `{"name": "pharetra placerat", "birth_date": "1988-09-05", "document": "BN 5236025"}`
+		rec := doRequest(h, http.MethodPost, "/screen", body)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status not correct. Expected %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+		}
+
+		var resp screenResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Hits) != 1 {
+			t.Fatalf("num of hits not correct. Expected 1, got %d", len(resp.Hits))
+		}
+		if hit := resp.Hits[0]; hit.Number != "1" || hit.Score != 1 || len(hit.Matched) != 3 {
+			t.Errorf("hit not correct. Got %+v", hit)
+		}
+	})
+
+	t.Run("when request is not correct", func(t *testing.T) {
+		for _, body := range []string{`not json`, `{"birth_date": "05.09.1988"}`} {
+			if rec := doRequest(h, http.MethodPost, "/screen", body); rec.Code != http.StatusBadRequest {
+				t.Errorf("status for body %s not correct. Expected %d, got %d", body, http.StatusBadRequest, rec.Code)
+			}
+		}
+	})
+
+	t.Run("when request body is too large", func(t *testing.T) {
+		body := `{"name": "` + strings.Repeat("a", maxScreenBodySize) + `"}`
+		if rec := doRequest(h, http.MethodPost, "/screen", body); rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("status not correct. Expected %d, got %d", http.StatusRequestEntityTooLarge, rec.Code)
+		}
+	})
+}
+
+func TestServer_handleReload(t *testing.T) {
+	var calls int32
+	srv, err := newServer(filePath, func(path string) (*screen.Index, error) {
+		if atomic.AddInt32(&calls, 1) > 2 {
+			return nil, errors.New("broken file")
+		}
+		return screen.NewIndex(make([]terreader.Row, calls)), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := srv.handler()
+
+	rec := doRequest(h, http.MethodPost, "/reload", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status not correct. Expected %d, got %d", http.StatusOK, rec.Code)
+	}
+	if srv.currentIndex().Len() != 2 {
+		t.Errorf("index not replaced. Expected 2 records, got %d", srv.currentIndex().Len())
+	}
+
+	rec = doRequest(h, http.MethodPost, "/reload", "")
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status not correct. Expected %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	if srv.currentIndex().Len() != 2 {
+		t.Errorf("index replaced by broken file. Expected 2 records, got %d", srv.currentIndex().Len())
+	}
+
+	if rec := doRequest(h, http.MethodGet, "/reload", ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status not correct. Expected %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import "fmt"
+
+// ConflictPolicy says which value is used if rows of record have different values of static, enum or date field.
+type ConflictPolicy int
+
+// Policies of conflicts resolving.
+const (
+	// ConflictFirst uses value of the first row. It is the default policy. Value of static or date field
+	// is taken from the first row even if it is empty, enum field gets the first allowed value.
+	ConflictFirst ConflictPolicy = iota
+	// ConflictLast uses value of the last row. Empty values are not taken into account by this and next policies.
+	ConflictLast
+	// ConflictMostCommon uses value which is found in the most rows, value of the earlier row wins in case of a tie.
+	ConflictMostCommon
+	// ConflictError makes Read return error for the record.
+	ConflictError
+)
+
+// fieldValue structure for store value of field in row of record.
+type fieldValue struct {
+	value string
+	rowID uint64
+	index int
+}
+
+// resolveConflict chooses value of field by policy. Values must be ordered by ROW_ID and must not be empty,
+// except the value of the first row with ConflictFirst policy.
+// Diagnostics are returned for rows with values different from the chosen one.
+func resolveConflict(policy ConflictPolicy, fieldName string, values []fieldValue) (string, []Diagnostic, error) {
+	if len(values) == 0 {
+		return "", nil, nil
+	}
+
+	chosen := values[0]
+	switch policy {
+	case ConflictLast:
+		chosen = values[len(values)-1]
+	case ConflictMostCommon:
+		counts := make(map[string]int)
+		for _, v := range values {
+			counts[v.value]++
+		}
+		for _, v := range values {
+			if counts[v.value] > counts[chosen.value] {
+				chosen = v
+			}
+		}
+	}
+
+	var diagnostics []Diagnostic
+	for _, v := range values {
+		if v.value == chosen.value {
+			continue
+		}
+
+		if policy == ConflictError {
+			err := fmt.Errorf("field '%s' has conflicting values '%s' and '%s'", fieldName, chosen.value, v.value)
+
+			return "", nil, newRecordError(ErrorKindConflict, fieldName, err)
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Kind:    DiagnosticConflict,
+			Field:   fieldName,
+			RowID:   v.rowID,
+			Message: fmt.Sprintf("value '%s' is ignored, value '%s' is used", v.value, chosen.value),
+		})
+	}
+
+	return chosen.value, diagnostics, nil
+}
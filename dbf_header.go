@@ -0,0 +1,149 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	dbfHeaderSize          = 32
+	dbfFieldDescriptorSize = 32
+	dbfFieldTerminator     = 0x0D
+	dbfLanguageDriverPos   = 29
+)
+
+// dbfHeader structure for store header of dbf file.
+// go-dbf exposes only field descriptors by Fields(), so header values are parsed from raw data.
+type dbfHeader struct {
+	signature       byte
+	updateYear      byte
+	updateMonth     byte
+	updateDay       byte
+	numberOfRecords uint32
+	headerLength    uint16
+	recordLength    uint16
+	languageDriver  byte
+	fields          []dbfField
+}
+
+// dbfField structure for store field descriptor. Offset is a position of the field in record.
+type dbfField struct {
+	name      string
+	fieldType byte
+	length    byte
+	decimals  byte
+	offset    int
+}
+
+func parseDBFHeader(data []byte) (*dbfHeader, error) {
+	if len(data) < dbfHeaderSize+1 {
+		return nil, errors.New("dbf header is too short")
+	}
+
+	h := &dbfHeader{
+		signature:       data[0],
+		updateYear:      data[1],
+		updateMonth:     data[2],
+		updateDay:       data[3],
+		numberOfRecords: binary.LittleEndian.Uint32(data[4:8]),
+		headerLength:    binary.LittleEndian.Uint16(data[8:10]),
+		recordLength:    binary.LittleEndian.Uint16(data[10:12]),
+		languageDriver:  data[dbfLanguageDriverPos],
+	}
+	if int(h.headerLength) > len(data) || h.headerLength < dbfHeaderSize+1 {
+		return nil, fmt.Errorf("dbf header length '%d' is not correct", h.headerLength)
+	}
+
+	// Deletion flag is stored before fields.
+	offset := 1
+	for pos := dbfHeaderSize; pos+dbfFieldDescriptorSize <= int(h.headerLength); pos += dbfFieldDescriptorSize {
+		if data[pos] == dbfFieldTerminator {
+			break
+		}
+
+		descriptor := data[pos : pos+dbfFieldDescriptorSize]
+		name := descriptor[:11]
+		if i := bytes.IndexByte(name, 0); i >= 0 {
+			name = name[:i]
+		}
+
+		field := dbfField{
+			name:      string(name),
+			fieldType: descriptor[11],
+			length:    descriptor[16],
+			decimals:  descriptor[17],
+			offset:    offset,
+		}
+		h.fields = append(h.fields, field)
+		offset += int(field.length)
+	}
+
+	if offset > int(h.recordLength) {
+		return nil, fmt.Errorf("dbf record length '%d' is less than length of fields '%d'", h.recordLength, offset)
+	}
+
+	return h, nil
+}
+
+// fillBlankPadding replaces NUL bytes in records area of data by spaces. go-dbf does it in place on every read
+// of a value, which is a data race for simultaneous reads, so it is done once before the data is shared.
+func fillBlankPadding(data []byte) {
+	if len(data) < dbfHeaderSize {
+		return
+	}
+	headerLength := int(binary.LittleEndian.Uint16(data[8:10]))
+	if headerLength > len(data) {
+		return
+	}
+
+	records := data[headerLength:]
+	for i := range records {
+		if records[i] == 0 {
+			records[i] = ' '
+		}
+	}
+}
+
+// record returns raw data of the record with provided index or nil if data is too short.
+func (h *dbfHeader) record(data []byte, index int) []byte {
+	start := int(h.headerLength) + index*int(h.recordLength)
+	end := start + int(h.recordLength)
+	if end > len(data) {
+		return nil
+	}
+
+	return data[start:end]
+}
+
+// lastUpdate returns date of the last update of the file. Year is stored as a number of years since 1900,
+// but some programs store only two last digits of year, such years are treated as 1980-2079.
+func (h *dbfHeader) lastUpdate() time.Time {
+	year := int(h.updateYear)
+	switch {
+	case year >= 100:
+		year += 1900
+	case year < 80:
+		year += 2000
+	default:
+		year += 1900
+	}
+
+	return time.Date(year, time.Month(h.updateMonth), int(h.updateDay), 0, 0, 0, 0, time.UTC)
+}
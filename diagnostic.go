@@ -0,0 +1,44 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+// DiagnosticKind is a kind of questionable data which was found while record was built.
+type DiagnosticKind string
+
+// Kinds of diagnostics.
+const (
+	// DiagnosticRepeatedFragment is reported when part of text value from continuation row is included
+	// in the joined text but is not a duplicate of other part. Such part is appended to the text.
+	DiagnosticRepeatedFragment DiagnosticKind = "repeated_fragment"
+	// DiagnosticSeparatorInserted is reported when space is inserted before part of text value, because
+	// the previous part is one character shorter than width of the field. The space can be absent
+	// in the original value if the previous part just ends there.
+	DiagnosticSeparatorInserted DiagnosticKind = "separator_inserted"
+	// DiagnosticConflict is reported in strict mode when rows of record have different values
+	// of static, enum or date field. Value is chosen by ConflictPolicy.
+	DiagnosticConflict DiagnosticKind = "conflict"
+	// DiagnosticUnknownEnumValue is reported when value of enum field is not allowed
+	// and it is used because of AllowUnknownEnumValues option.
+	DiagnosticUnknownEnumValue DiagnosticKind = "unknown_enum_value"
+)
+
+// Diagnostic structure for store information about questionable data of the record.
+// RowID is a value of ROW_ID column of the row where data was found.
+type Diagnostic struct {
+	Kind    DiagnosticKind
+	Field   string
+	RowID   uint64
+	Message string
+}
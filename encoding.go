@@ -0,0 +1,119 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import "unicode"
+
+// Encodings of terrorists database files.
+const (
+	// EncodingAuto makes constructor detect encoding of the file.
+	EncodingAuto = "auto"
+	// EncodingCP866 is DOS Cyrillic encoding.
+	EncodingCP866 = "866"
+	// EncodingWindows1251 is Windows Cyrillic encoding.
+	EncodingWindows1251 = "windows-1251"
+)
+
+// encodingSampleRecords is a number of records which are used for detecting encoding by text.
+const encodingSampleRecords = 1000
+
+// detectEncoding returns encoding of dbf file. Language driver byte of the header is used if it is set,
+// otherwise bytes of character fields are read as Cyrillic letters in both encodings and the encoding
+// with more frequent letters of Russian alphabet is chosen. Frequencies are needed because lowercase letters
+// of Windows-1251 are lowercase letters in CP866 too. CP866 is returned if there is no difference.
+func detectEncoding(data []byte, header *dbfHeader) string {
+	switch header.languageDriver {
+	case 0x26, 0x65:
+		return EncodingCP866
+	case 0xC9:
+		return EncodingWindows1251
+	}
+
+	var cp866Score, cp1251Score int
+	for i := 0; i < int(header.numberOfRecords) && i < encodingSampleRecords; i++ {
+		record := header.record(data, i)
+		if record == nil {
+			break
+		}
+
+		for _, field := range header.fields {
+			if field.fieldType != 'C' {
+				continue
+			}
+
+			for _, b := range record[field.offset : field.offset+int(field.length)] {
+				cp866Score += letterFrequency(cp866Letter(b))
+				cp1251Score += letterFrequency(windows1251Letter(b))
+			}
+		}
+	}
+
+	if cp1251Score > cp866Score {
+		return EncodingWindows1251
+	}
+
+	return EncodingCP866
+}
+
+// russianLetterFrequencies are frequencies of lowercase letters from 'а' to 'я' in Russian texts, per mille.
+var russianLetterFrequencies = [32]int{
+	80, 16, 45, 17, 30, 85, 9, 16, 74, 12, 35, 44, 32, 67, 110, 28,
+	47, 55, 63, 26, 3, 10, 5, 14, 7, 4, 1, 19, 17, 3, 6, 20,
+}
+
+// letterFrequency returns frequency of Cyrillic letter in Russian texts, it is 0 if r is not a letter.
+func letterFrequency(r rune) int {
+	r = unicode.ToLower(r)
+	switch {
+	case r == 'ё':
+		return 1
+	case r >= 'а' && r <= 'я':
+		return russianLetterFrequencies[r-'а']
+	}
+
+	return 0
+}
+
+// cp866Letter returns Cyrillic letter of byte in CP866 or 0 if byte is not a letter.
+func cp866Letter(b byte) rune {
+	switch {
+	case b >= 0x80 && b <= 0x9F:
+		return 'А' + rune(b-0x80)
+	case b >= 0xA0 && b <= 0xAF:
+		return 'а' + rune(b-0xA0)
+	case b >= 0xE0 && b <= 0xEF:
+		return 'р' + rune(b-0xE0)
+	case b == 0xF0:
+		return 'Ё'
+	case b == 0xF1:
+		return 'ё'
+	}
+
+	return 0
+}
+
+// windows1251Letter returns letter of Russian alphabet of byte in Windows-1251 or 0 if byte is not a letter.
+func windows1251Letter(b byte) rune {
+	switch {
+	case b >= 0xC0:
+		return 'А' + rune(b-0xC0)
+	case b == 0xA8:
+		return 'Ё'
+	case b == 0xB8:
+		return 'ё'
+	}
+
+	return 0
+}
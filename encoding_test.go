@@ -0,0 +1,104 @@
+package terreader
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/axgle/mahonia"
+)
+
+// testFileWithName returns test file data with NAMEU of the first record encoded in provided encoding.
+func testFileWithName(t *testing.T, name, encoding string, languageDriver byte) []byte {
+	data := readTestFile(t)
+
+	header, err := parseDBFHeader(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data[dbfLanguageDriverPos] = languageDriver
+	record := header.record(data, 0)
+	for _, field := range header.fields {
+		if field.name != "NAMEU" {
+			continue
+		}
+		value := []byte(mahonia.NewEncoder(encoding).ConvertString(name))
+		value = append(value, bytes.Repeat([]byte(" "), int(field.length)-len(value))...)
+		copy(record[field.offset:], value)
+	}
+
+	return data
+}
+
+func TestNewTerReaderFromByteSlice_EncodingAuto(t *testing.T) {
+	const name = "ИВАНОВ ИВАН ИВАНОВИЧ, Г. МОСКВА"
+
+	testCases := []struct {
+		title          string
+		name           string
+		fileEncoding   string
+		languageDriver byte
+		detected       string
+	}{
+		{"cp866 by text", name, EncodingCP866, 0, EncodingCP866},
+		{"windows-1251 by text", name, EncodingWindows1251, 0, EncodingWindows1251},
+		{"lowercase cp866 by text", "редиска", EncodingCP866, 0, EncodingCP866},
+		{"lowercase windows-1251 by text", "редиска", EncodingWindows1251, 0, EncodingWindows1251},
+		{"mixed case windows-1251 by text", "Иванов Пётр, г. Тверь", EncodingWindows1251, 0, EncodingWindows1251},
+		{"cp866 by language driver", name, EncodingCP866, 0x65, EncodingCP866},
+		{"windows-1251 by language driver", name, EncodingWindows1251, 0xC9, EncodingWindows1251},
+		{"language driver has priority", name, EncodingWindows1251, 0x26, EncodingCP866},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			data := testFileWithName(t, testCase.name, testCase.fileEncoding, testCase.languageDriver)
+
+			tr, err := NewTerReaderFromByteSlice(data, EncodingAuto)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tr.DetectedEncoding() != testCase.detected {
+				t.Errorf("detected encoding not correct. Expected %s, got %s", testCase.detected, tr.DetectedEncoding())
+			}
+
+			if testCase.fileEncoding != testCase.detected {
+				return
+			}
+
+			nameu, err := tr.table.FieldValueByName(0, "NAMEU")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if nameu != testCase.name {
+				t.Errorf("NAMEU not correct. Expected \"%s\", got \"%s\"", testCase.name, nameu)
+			}
+		})
+	}
+}
+
+func TestTerReader_DetectedEncoding(t *testing.T) {
+	tr, err := NewTerReader(filePath, fileEncoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.DetectedEncoding() != fileEncoding {
+		t.Errorf("encoding not correct. Expected %s, got %s", fileEncoding, tr.DetectedEncoding())
+	}
+
+	tr, err = NewTerReader(filePath, EncodingAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.DetectedEncoding() != EncodingCP866 {
+		t.Errorf("encoding for file without Cyrillic text not correct. Expected %s, got %s", EncodingCP866, tr.DetectedEncoding())
+	}
+
+	tr, err = NewTerReaderFromByteSlice([]byte("short"), EncodingAuto)
+	if err == nil || err.Error() != "dbf header is too short" {
+		t.Errorf("error not correct. Expected \"dbf header is too short\", got %v", err)
+	}
+	if tr != nil {
+		t.Errorf("get not correct TerReader. Expected nil, got %+v", tr)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const fingerprintPrefix = "sha256:"
+
+// Manifest structure for store fingerprint of the list and its statistics.
+// Manifest can be written next to exports to prove which list was used.
+type Manifest struct {
+	Fingerprint string         `json:"fingerprint"`
+	Records     int            `json:"records"`
+	ByTu        map[string]int `json:"by_tu"`
+	ByTerror    map[string]int `json:"by_terror"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// Fingerprint reads all records and computes a stable hash over them.
+// Hash does not depend on order of rows in the file, their padding and ROW_ID values,
+// so two files with the same records have the same fingerprint.
+func (tr *TerReader) Fingerprint() (*Manifest, error) {
+	ctx := tr.context()
+	results, err := tr.ReadContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{
+		ByTu:      make(map[string]int),
+		ByTerror:  make(map[string]int),
+		CreatedAt: time.Now().UTC(),
+	}
+	h := sha256.New()
+
+	for res := range results {
+		if res.Error != nil {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("error for record with number '%d': %w", res.Number, res.Error)
+		}
+
+		writeNormalizedRow(h, res.Row)
+		m.Records++
+		m.ByTu[res.Row.Tu]++
+		m.ByTerror[res.Row.Terror]++
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.Fingerprint = fingerprintPrefix + hex.EncodeToString(h.Sum(nil))
+
+	return m, nil
+}
+
+// writeNormalizedRow writes values of the row to the hash in order of Row fields.
+// Records are read ordered by number, so order of rows in the file does not affect the hash.
+func writeNormalizedRow(h hash.Hash, row *Row) {
+	val := reflect.ValueOf(row).Elem()
+
+	for i := 0; i < val.NumField(); i++ {
+		fieldName := val.Type().Field(i).Tag.Get("tr_col")
+		if fieldName == "ROW_ID" {
+			continue
+		}
+
+		var value string
+		switch v := val.Field(i).Interface().(type) {
+		case string:
+			value = strings.Join(strings.Fields(v), " ")
+		case *time.Time:
+			if v != nil {
+				value = v.Format(dateFormat)
+			}
+		case []Document:
+			fieldName = "DOCUMENTS"
+			for _, doc := range v {
+				value += doc.Kd + "|" + doc.Sd + "|" + doc.Nd + "|" + doc.Vd + ";"
+			}
+		}
+
+		io.WriteString(h, fieldName+"="+value+"\x1f") //nolint:errcheck
+	}
+
+	io.WriteString(h, "\x1e") //nolint:errcheck
+}
+
+// WriteTo writes manifest to w as JSON.
+func (m *Manifest) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(append(b, '\n'))
+
+	return int64(n), err
+}
+
+// WriteFile writes manifest to the file as JSON.
+func (m *Manifest) WriteFile(filePath string) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filePath, append(b, '\n'), 0o644)
+}
+
+// ReadManifest reads manifest from the JSON file.
+func ReadManifest(filePath string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
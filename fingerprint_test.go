@@ -0,0 +1,113 @@
+package terreader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func fingerprintOf(t *testing.T, rows []map[string]string) *Manifest {
+	tr := TerReader{table: NewMapTable(rows), ctx: context.Background()}
+
+	m, err := tr.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestTerReader_Fingerprint(t *testing.T) {
+	t.Run("when rows order, padding and row ids differ", func(t *testing.T) {
+		rows := getSuccessTestRows()
+
+		reordered := make([]map[string]string, 0, len(rows))
+		for i := len(rows) - 1; i >= 0; i-- {
+			row := make(map[string]string, len(rows[i]))
+			for k, v := range rows[i] {
+				row[k] = v
+			}
+			row["ROW_ID"] = row["ROW_ID"] + "0"
+			row["MR"] = " " + row["MR"] + "  "
+			reordered = append(reordered, row)
+		}
+
+		m1 := fingerprintOf(t, rows)
+		m2 := fingerprintOf(t, reordered)
+		if m1.Fingerprint != m2.Fingerprint {
+			t.Errorf("fingerprints not equal. First %s, second %s", m1.Fingerprint, m2.Fingerprint)
+		}
+		if !strings.HasPrefix(m1.Fingerprint, "sha256:") || len(m1.Fingerprint) != 71 {
+			t.Errorf("fingerprint format not correct. Got %s", m1.Fingerprint)
+		}
+
+		if m1.Records != 6 {
+			t.Errorf("records count not correct. Expected 6, got %d", m1.Records)
+		}
+		etalonByTu := map[string]int{"1": 3, "2": 1, "3": 2}
+		if !reflect.DeepEqual(m1.ByTu, etalonByTu) {
+			t.Errorf("counts by TU not correct. Expected %v, got %v", etalonByTu, m1.ByTu)
+		}
+		etalonByTerror := map[string]int{"0": 1, "1": 5}
+		if !reflect.DeepEqual(m1.ByTerror, etalonByTerror) {
+			t.Errorf("counts by TERROR not correct. Expected %v, got %v", etalonByTerror, m1.ByTerror)
+		}
+	})
+
+	t.Run("when records differ", func(t *testing.T) {
+		rows := getSuccessTestRows()
+		m1 := fingerprintOf(t, rows)
+
+		rows[0]["VD"] = "changed"
+		m2 := fingerprintOf(t, rows)
+
+		if m1.Fingerprint == m2.Fingerprint {
+			t.Error("fingerprints of different records are equal")
+		}
+	})
+
+	t.Run("when record has error", func(t *testing.T) {
+		rows := getSuccessTestRows()
+		rows[0]["TU"] = "not_support"
+		rows[1]["TU"] = "not_support"
+
+		tr := TerReader{table: NewMapTable(rows), ctx: context.Background()}
+		m, err := tr.Fingerprint()
+		etalonError := errors.New("error for record with number '1': can not find a suitable value for 'TU'")
+		if err == nil || err.Error() != etalonError.Error() {
+			t.Errorf("error not correct. Expected \"%s\", got %v", etalonError, err)
+		}
+		if m != nil {
+			t.Errorf("get not correct Manifest. Expected nil, got %+v", m)
+		}
+	})
+}
+
+func TestManifest_WriteFile(t *testing.T) {
+	m := fingerprintOf(t, getSuccessTestRows())
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	if err := m.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := ReadManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Fingerprint != m.Fingerprint || !read.CreatedAt.Equal(m.CreatedAt) || !reflect.DeepEqual(read.ByTu, m.ByTu) {
+		t.Errorf("read manifest not correct. Expected %+v, got %+v", *m, *read)
+	}
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"fingerprint": "`+m.Fingerprint+`"`) {
+		t.Errorf("written manifest not correct. Got %s", buf.String())
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command terreader-grpc starts gRPC screening service.
+//
+// Usage:
+//
+//	terreader-grpc -file /path/to/file.dbf [-encoding 866] [-addr :9090]
+//
+// SIGHUP makes the service read the file again, records are replaced only if the file was read without errors.
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/will-evil/terreader"
+	"github.com/will-evil/terreader/grpc/server"
+	"github.com/will-evil/terreader/grpc/terreaderpb"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("terreader-grpc", flag.ContinueOnError)
+	filePath := flags.String("file", "", "path to dbf file")
+	encoding := flags.String("encoding", "866", "encoding of dbf file")
+	addr := flags.String("addr", ":9090", "address for listening")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *filePath == "" {
+		return errors.New("flag -file is required")
+	}
+
+	srv, err := server.New(func() (*terreader.TerReader, error) {
+		return terreader.NewTerReader(*filePath, *encoding)
+	})
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer()
+	terreaderpb.RegisterTerReaderServiceServer(grpcServer, srv)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	go func() {
+		for sig := range signals {
+			if sig != syscall.SIGHUP {
+				grpcServer.GracefulStop()
+				return
+			}
+			if err := srv.Reload(); err != nil {
+				log.Printf("reload error: %v", err)
+				continue
+			}
+			log.Print("file is reloaded")
+		}
+	}()
+
+	log.Printf("listening on %s", *addr)
+
+	return grpcServer.Serve(lis)
+}
@@ -0,0 +1,79 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/will-evil/terreader"
+	"github.com/will-evil/terreader/grpc/terreaderpb"
+)
+
+func toProtoRow(row *terreader.Row) *terreaderpb.Row {
+	var documents []*terreaderpb.Document
+	for _, doc := range row.OtherDocuments {
+		documents = append(documents, &terreaderpb.Document{Kd: doc.Kd, Sd: doc.Sd, Nd: doc.Nd, Vd: doc.Vd})
+	}
+
+	return &terreaderpb.Row{
+		Number:   row.Number,
+		Terror:   row.Terror,
+		Tu:       row.Tu,
+		Nameu:    row.Nameu,
+		Descript: row.Descript,
+		Kodcr:    row.Kodcr,
+		Kodcn:    row.Kodcn,
+		Amr:      row.Amr,
+		Address:  row.Address,
+		Kd:       row.Kd,
+		Sd:       row.Sd,
+		Rg:       row.Rg,
+		Nd:       row.Nd,
+		Vd:       row.Vd,
+		Gr:       toProtoTime(row.Gr),
+		Yr:       row.Yr,
+		Mr:       row.Mr,
+		CbDate:   toProtoTime(row.CbDate),
+		CeDate:   toProtoTime(row.CeDate),
+		Director: row.Director,
+		Founder:  row.Founder,
+		RowId:    row.RowID,
+		Terrtype: row.Terrtype,
+
+		OtherDocuments: documents,
+	}
+}
+
+func toProtoResult(res terreader.RowReadResult) *terreaderpb.RowReadResult {
+	pbRes := &terreaderpb.RowReadResult{Number: res.Number}
+	if res.Row != nil {
+		pbRes.Row = toProtoRow(res.Row)
+	}
+	if res.Error != nil {
+		pbRes.Error = res.Error.Error()
+	}
+
+	return pbRes
+}
+
+func toProtoTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,130 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package server provides implementation of terreader.v1.TerReaderService gRPC service.
+package server
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/will-evil/terreader"
+	"github.com/will-evil/terreader/grpc/terreaderpb"
+	"github.com/will-evil/terreader/screen"
+)
+
+const birthDateFormat = "2006-01-02"
+
+// OpenFunc opens new reader for the list file.
+type OpenFunc func() (*terreader.TerReader, error)
+
+// Server structure that implements TerReaderService. All methods are served from in-memory index,
+// so they return the same list until the index is replaced by Reload.
+type Server struct {
+	terreaderpb.UnimplementedTerReaderServiceServer
+
+	open     OpenFunc
+	index    atomic.Value
+	reloadMu sync.Mutex
+}
+
+// New is a constructor for Server structure. It reads the file and builds index.
+func New(open OpenFunc) (*Server, error) {
+	s := &Server{open: open}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Reload reads the file again and replaces index if the file was read without errors.
+func (s *Server) Reload() error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	tr, err := s.open()
+	if err != nil {
+		return err
+	}
+	defer tr.Close()
+
+	idx, err := screen.Load(tr)
+	if err != nil {
+		return err
+	}
+	s.index.Store(idx)
+
+	return nil
+}
+
+func (s *Server) currentIndex() *screen.Index {
+	return s.index.Load().(*screen.Index)
+}
+
+// ReadAll streams all records of the index ordered by number.
+func (s *Server) ReadAll(_ *terreaderpb.ReadAllRequest, stream terreaderpb.TerReaderService_ReadAllServer) error {
+	rows := s.currentIndex().Rows()
+	for i := range rows {
+		number, err := strconv.ParseUint(rows[i].Number, 10, 64)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if err := stream.Send(toProtoResult(terreader.RowReadResult{Row: &rows[i], Number: number})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get returns record by number.
+func (s *Server) Get(_ context.Context, req *terreaderpb.GetRequest) (*terreaderpb.Row, error) {
+	row, ok := s.currentIndex().Get(req.GetNumber())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "record with number '%s' not found", req.GetNumber())
+	}
+
+	return toProtoRow(&row), nil
+}
+
+// Screen returns records which match the query.
+func (s *Server) Screen(_ context.Context, req *terreaderpb.ScreenRequest) (*terreaderpb.ScreenResponse, error) {
+	query := screen.Query{Name: req.GetName(), Document: req.GetDocument()}
+	if req.GetBirthDate() != "" {
+		birthDate, err := time.Parse(birthDateFormat, req.GetBirthDate())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query.BirthDate = &birthDate
+	}
+
+	hits := s.currentIndex().Screen(query, int(req.GetLimit()))
+	resp := &terreaderpb.ScreenResponse{Hits: make([]*terreaderpb.Hit, 0, len(hits))}
+	for i := range hits {
+		resp.Hits = append(resp.Hits, &terreaderpb.Hit{
+			Row:     toProtoRow(&hits[i].Row),
+			Score:   hits[i].Score,
+			Matched: hits[i].Matched,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/will-evil/terreader"
+	"github.com/will-evil/terreader/grpc/terreaderpb"
+)
+
+const fileEncoding = "866"
+const filePath = "../../test/data/testfile.dbf"
+
+func newTestClient(t *testing.T) terreaderpb.TerReaderServiceClient {
+	srv, err := New(func() (*terreader.TerReader, error) {
+		return terreader.NewTerReader(filePath, fileEncoding)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newTestClientForServer(t, srv)
+}
+
+func newTestClientForServer(t *testing.T, srv *Server) terreaderpb.TerReaderServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	terreaderpb.RegisterTerReaderServiceServer(grpcServer, srv)
+	go grpcServer.Serve(lis) //nolint:errcheck
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return terreaderpb.NewTerReaderServiceClient(conn)
+}
+
+func TestNew(t *testing.T) {
+	etalonError := errors.New("open error")
+	srv, err := New(func() (*terreader.TerReader, error) {
+		return nil, etalonError
+	})
+	if err != etalonError {
+		t.Errorf("error object not correct. Expected %v, got %v", etalonError, err)
+	}
+	if srv != nil {
+		t.Errorf("get not correct Server. Expected nil, got %v", srv)
+	}
+}
+
+func TestServer_ReadAll(t *testing.T) {
+	client := newTestClient(t)
+
+	stream, err := client.ReadAll(context.Background(), &terreaderpb.ReadAllRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var results []*terreaderpb.RowReadResult
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, res)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("received not correct num of records. Expected 1, got %d", len(results))
+	}
+	res := results[0]
+	if res.GetNumber() != 1 || res.GetError() != "" {
+		t.Errorf("result not correct. Got %v", res)
+	}
+	if row := res.GetRow(); row.GetNameu() != "Pharetra magna ac placerat" || row.GetGr().AsTime().Year() != 1988 || row.GetCeDate() != nil {
+		t.Errorf("get not correct Row. Got %v", row)
+	}
+}
+
+func TestServer_ReadAll_WhenFileIsChanged(t *testing.T) {
+	opened := false
+	srv, err := New(func() (*terreader.TerReader, error) {
+		if opened {
+			return nil, errors.New("file is changed")
+		}
+		opened = true
+
+		return terreader.NewTerReader(filePath, fileEncoding)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newTestClientForServer(t, srv)
+
+	stream, err := client.ReadAll(context.Background(), &terreaderpb.ReadAllRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, err := client.Get(context.Background(), &terreaderpb.GetRequest{Number: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetRow().GetNameu() != row.GetNameu() {
+		t.Errorf("streamed record not correct. Expected %s, got %s", row.GetNameu(), res.GetRow().GetNameu())
+	}
+}
+
+func TestServer_Get(t *testing.T) {
+	client := newTestClient(t)
+
+	row, err := client.Get(context.Background(), &terreaderpb.GetRequest{Number: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.GetRg() != "BN 5236025" {
+		t.Errorf("get not correct Row. Got %v", row)
+	}
+
+	_, err = client.Get(context.Background(), &terreaderpb.GetRequest{Number: "2"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("error code not correct. Expected %s, got %v", codes.NotFound, err)
+	}
+}
+
+func TestServer_Screen(t *testing.T) {
+	client := newTestClient(t)
+
+	resp, err := client.Screen(context.Background(), &terreaderpb.ScreenRequest{
+		Name:      "pharetra placerat",
+		BirthDate: "1988-09-05",
+		Document:  "BN 5236025",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetHits()) != 1 {
+		t.Fatalf("num of hits not correct. Expected 1, got %d", len(resp.GetHits()))
+	}
+	if hit := resp.GetHits()[0]; hit.GetRow().GetNumber() != "1" || hit.GetScore() != 1 || len(hit.GetMatched()) != 3 {
+		t.Errorf("hit not correct. Got %v", hit)
+	}
+
+	_, err = client.Screen(context.Background(), &terreaderpb.ScreenRequest{BirthDate: "05.09.1988"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error code not correct. Expected %s, got %v", codes.InvalidArgument, err)
+	}
+}
@@ -0,0 +1,939 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: terreader/v1/terreader.proto
+
+package terreaderpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Row is a record of terrorists database. Fields are named after columns of the dbf file.
+type Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number   string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Terror   string                 `protobuf:"bytes,2,opt,name=terror,proto3" json:"terror,omitempty"`
+	Tu       string                 `protobuf:"bytes,3,opt,name=tu,proto3" json:"tu,omitempty"`
+	Nameu    string                 `protobuf:"bytes,4,opt,name=nameu,proto3" json:"nameu,omitempty"`
+	Descript string                 `protobuf:"bytes,5,opt,name=descript,proto3" json:"descript,omitempty"`
+	Kodcr    string                 `protobuf:"bytes,6,opt,name=kodcr,proto3" json:"kodcr,omitempty"`
+	Kodcn    string                 `protobuf:"bytes,7,opt,name=kodcn,proto3" json:"kodcn,omitempty"`
+	Amr      string                 `protobuf:"bytes,8,opt,name=amr,proto3" json:"amr,omitempty"`
+	Address  string                 `protobuf:"bytes,9,opt,name=address,proto3" json:"address,omitempty"`
+	Kd       string                 `protobuf:"bytes,10,opt,name=kd,proto3" json:"kd,omitempty"`
+	Sd       string                 `protobuf:"bytes,11,opt,name=sd,proto3" json:"sd,omitempty"`
+	Rg       string                 `protobuf:"bytes,12,opt,name=rg,proto3" json:"rg,omitempty"`
+	Nd       string                 `protobuf:"bytes,13,opt,name=nd,proto3" json:"nd,omitempty"`
+	Vd       string                 `protobuf:"bytes,14,opt,name=vd,proto3" json:"vd,omitempty"`
+	Gr       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=gr,proto3" json:"gr,omitempty"`
+	Yr       string                 `protobuf:"bytes,16,opt,name=yr,proto3" json:"yr,omitempty"`
+	Mr       string                 `protobuf:"bytes,17,opt,name=mr,proto3" json:"mr,omitempty"`
+	CbDate   *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=cb_date,json=cbDate,proto3" json:"cb_date,omitempty"`
+	CeDate   *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=ce_date,json=ceDate,proto3" json:"ce_date,omitempty"`
+	Director string                 `protobuf:"bytes,20,opt,name=director,proto3" json:"director,omitempty"`
+	Founder  string                 `protobuf:"bytes,21,opt,name=founder,proto3" json:"founder,omitempty"`
+	RowId    string                 `protobuf:"bytes,22,opt,name=row_id,json=rowId,proto3" json:"row_id,omitempty"`
+	Terrtype string                 `protobuf:"bytes,23,opt,name=terrtype,proto3" json:"terrtype,omitempty"`
+	// Documents of the record which differ from the one in kd, sd, nd and vd.
+	OtherDocuments []*Document `protobuf:"bytes,24,rep,name=other_documents,json=otherDocuments,proto3" json:"other_documents,omitempty"`
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_terreader_v1_terreader_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_terreader_v1_terreader_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_terreader_v1_terreader_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Row) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Row) GetTerror() string {
+	if x != nil {
+		return x.Terror
+	}
+	return ""
+}
+
+func (x *Row) GetTu() string {
+	if x != nil {
+		return x.Tu
+	}
+	return ""
+}
+
+func (x *Row) GetNameu() string {
+	if x != nil {
+		return x.Nameu
+	}
+	return ""
+}
+
+func (x *Row) GetDescript() string {
+	if x != nil {
+		return x.Descript
+	}
+	return ""
+}
+
+func (x *Row) GetKodcr() string {
+	if x != nil {
+		return x.Kodcr
+	}
+	return ""
+}
+
+func (x *Row) GetKodcn() string {
+	if x != nil {
+		return x.Kodcn
+	}
+	return ""
+}
+
+func (x *Row) GetAmr() string {
+	if x != nil {
+		return x.Amr
+	}
+	return ""
+}
+
+func (x *Row) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Row) GetKd() string {
+	if x != nil {
+		return x.Kd
+	}
+	return ""
+}
+
+func (x *Row) GetSd() string {
+	if x != nil {
+		return x.Sd
+	}
+	return ""
+}
+
+func (x *Row) GetRg() string {
+	if x != nil {
+		return x.Rg
+	}
+	return ""
+}
+
+func (x *Row) GetNd() string {
+	if x != nil {
+		return x.Nd
+	}
+	return ""
+}
+
+func (x *Row) GetVd() string {
+	if x != nil {
+		return x.Vd
+	}
+	return ""
+}
+
+func (x *Row) GetGr() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Gr
+	}
+	return nil
+}
+
+func (x *Row) GetYr() string {
+	if x != nil {
+		return x.Yr
+	}
+	return ""
+}
+
+func (x *Row) GetMr() string {
+	if x != nil {
+		return x.Mr
+	}
+	return ""
+}
+
+func (x *Row) GetCbDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CbDate
+	}
+	return nil
+}
+
+func (x *Row) GetCeDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CeDate
+	}
+	return nil
+}
+
+func (x *Row) GetDirector() string {
+	if x != nil {
+		return x.Director
+	}
+	return ""
+}
+
+func (x *Row) GetFounder() string {
+	if x != nil {
+		return x.Founder
+	}
+	return ""
+}
+
+func (x *Row) GetRowId() string {
+	if x != nil {
+		return x.RowId
+	}
+	return ""
+}
+
+func (x *Row) GetTerrtype() string {
+	if x != nil {
+		return x.Terrtype
+	}
+	return ""
+}
+
+func (x *Row) GetOtherDocuments() []*Document {
+	if x != nil {
+		return x.OtherDocuments
+	}
+	return nil
+}
+
+type Document struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kd string `protobuf:"bytes,1,opt,name=kd,proto3" json:"kd,omitempty"`
+	Sd string `protobuf:"bytes,2,opt,name=sd,proto3" json:"sd,omitempty"`
+	Nd string `protobuf:"bytes,3,opt,name=nd,proto3" json:"nd,omitempty"`
+	Vd string `protobuf:"bytes,4,opt,name=vd,proto3" json:"vd,omitempty"`
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_terreader_v1_terreader_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_terreader_v1_terreader_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_terreader_v1_terreader_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Document) GetKd() string {
+	if x != nil {
+		return x.Kd
+	}
+	return ""
+}
+
+func (x *Document) GetSd() string {
+	if x != nil {
+		return x.Sd
+	}
+	return ""
+}
+
+func (x *Document) GetNd() string {
+	if x != nil {
+		return x.Nd
+	}
+	return ""
+}
+
+func (x *Document) GetVd() string {
+	if x != nil {
+		return x.Vd
+	}
+	return ""
+}
+
+// RowReadResult is a result of record reading. Error is empty when record was read successfully.
+type RowReadResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Row    *Row   `protobuf:"bytes,1,opt,name=row,proto3" json:"row,omitempty"`
+	Number uint64 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Error  string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *RowReadResult) Reset() {
+	*x = RowReadResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_terreader_v1_terreader_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RowReadResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RowReadResult) ProtoMessage() {}
+
+func (x *RowReadResult) ProtoReflect() protoreflect.Message {
+	mi := &file_terreader_v1_terreader_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RowReadResult.ProtoReflect.Descriptor instead.
+func (*RowReadResult) Descriptor() ([]byte, []int) {
+	return file_terreader_v1_terreader_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RowReadResult) GetRow() *Row {
+	if x != nil {
+		return x.Row
+	}
+	return nil
+}
+
+func (x *RowReadResult) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *RowReadResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ReadAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReadAllRequest) Reset() {
+	*x = ReadAllRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_terreader_v1_terreader_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAllRequest) ProtoMessage() {}
+
+func (x *ReadAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_terreader_v1_terreader_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAllRequest.ProtoReflect.Descriptor instead.
+func (*ReadAllRequest) Descriptor() ([]byte, []int) {
+	return file_terreader_v1_terreader_proto_rawDescGZIP(), []int{3}
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number string `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_terreader_v1_terreader_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_terreader_v1_terreader_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_terreader_v1_terreader_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRequest) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+type ScreenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Birth date in YYYY-MM-DD format.
+	BirthDate string `protobuf:"bytes,2,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
+	Document  string `protobuf:"bytes,3,opt,name=document,proto3" json:"document,omitempty"`
+	// Maximum number of hits, zero means no limit.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ScreenRequest) Reset() {
+	*x = ScreenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_terreader_v1_terreader_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScreenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScreenRequest) ProtoMessage() {}
+
+func (x *ScreenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_terreader_v1_terreader_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScreenRequest.ProtoReflect.Descriptor instead.
+func (*ScreenRequest) Descriptor() ([]byte, []int) {
+	return file_terreader_v1_terreader_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ScreenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScreenRequest) GetBirthDate() string {
+	if x != nil {
+		return x.BirthDate
+	}
+	return ""
+}
+
+func (x *ScreenRequest) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *ScreenRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Hit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Row     *Row     `protobuf:"bytes,1,opt,name=row,proto3" json:"row,omitempty"`
+	Score   float64  `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Matched []string `protobuf:"bytes,3,rep,name=matched,proto3" json:"matched,omitempty"`
+}
+
+func (x *Hit) Reset() {
+	*x = Hit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_terreader_v1_terreader_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hit) ProtoMessage() {}
+
+func (x *Hit) ProtoReflect() protoreflect.Message {
+	mi := &file_terreader_v1_terreader_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hit.ProtoReflect.Descriptor instead.
+func (*Hit) Descriptor() ([]byte, []int) {
+	return file_terreader_v1_terreader_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Hit) GetRow() *Row {
+	if x != nil {
+		return x.Row
+	}
+	return nil
+}
+
+func (x *Hit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Hit) GetMatched() []string {
+	if x != nil {
+		return x.Matched
+	}
+	return nil
+}
+
+type ScreenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hits []*Hit `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+}
+
+func (x *ScreenResponse) Reset() {
+	*x = ScreenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_terreader_v1_terreader_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScreenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScreenResponse) ProtoMessage() {}
+
+func (x *ScreenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_terreader_v1_terreader_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScreenResponse.ProtoReflect.Descriptor instead.
+func (*ScreenResponse) Descriptor() ([]byte, []int) {
+	return file_terreader_v1_terreader_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScreenResponse) GetHits() []*Hit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+var File_terreader_v1_terreader_proto protoreflect.FileDescriptor
+
+var file_terreader_v1_terreader_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x74,
+	0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c,
+	0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xff, 0x04,
+	0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x74, 0x75, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x75, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x75, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x6f, 0x64, 0x63, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x6f, 0x64, 0x63, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x6b, 0x6f, 0x64, 0x63, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x6f,
+	0x64, 0x63, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x61, 0x6d, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x0e, 0x0a, 0x02, 0x6b, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6b, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x73, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x73, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x72, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x67, 0x12,
+	0x0e, 0x0a, 0x02, 0x6e, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6e, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x76, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x76, 0x64, 0x12,
+	0x2a, 0x0a, 0x02, 0x67, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x67, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x79,
+	0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x79, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x6d,
+	0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6d, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x63,
+	0x62, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x63, 0x62, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x33, 0x0a, 0x07, 0x63, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x63,
+	0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x15, 0x0a, 0x06, 0x72,
+	0x6f, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x77,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x72, 0x72, 0x74, 0x79, 0x70, 0x65, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x72, 0x72, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3f,
+	0x0a, 0x0f, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x65, 0x72, 0x72, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x0e, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x4a, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6b,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6b, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x73,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x73, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6e,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x76,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x76, 0x64, 0x22, 0x62, 0x0a, 0x0d, 0x52,
+	0x6f, 0x77, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x23, 0x0a, 0x03,
+	0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x72, 0x72,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x03, 0x72, 0x6f,
+	0x77, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22,
+	0x10, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x24, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x74, 0x0a, 0x0d, 0x53, 0x63, 0x72, 0x65, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x62, 0x69, 0x72, 0x74, 0x68, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x62, 0x69, 0x72, 0x74, 0x68, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x5a, 0x0a,
+	0x03, 0x48, 0x69, 0x74, 0x12, 0x23, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x6f, 0x77, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x22, 0x37, 0x0a, 0x0e, 0x53, 0x63, 0x72,
+	0x65, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x68,
+	0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x72, 0x72,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x74, 0x52, 0x04, 0x68, 0x69,
+	0x74, 0x73, 0x32, 0xd3, 0x01, 0x0a, 0x10, 0x54, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x07, 0x52, 0x65, 0x61, 0x64, 0x41,
+	0x6c, 0x6c, 0x12, 0x1c, 0x2e, 0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x6f, 0x77, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x30, 0x01, 0x12,
+	0x32, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x18, 0x2e, 0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x6f, 0x77, 0x12, 0x43, 0x0a, 0x06, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x12, 0x1b, 0x2e,
+	0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x72,
+	0x65, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x65, 0x72,
+	0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x69, 0x6c, 0x6c, 0x2d, 0x65, 0x76, 0x69, 0x6c,
+	0x2f, 0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
+	0x74, 0x65, 0x72, 0x72, 0x65, 0x61, 0x64, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_terreader_v1_terreader_proto_rawDescOnce sync.Once
+	file_terreader_v1_terreader_proto_rawDescData = file_terreader_v1_terreader_proto_rawDesc
+)
+
+func file_terreader_v1_terreader_proto_rawDescGZIP() []byte {
+	file_terreader_v1_terreader_proto_rawDescOnce.Do(func() {
+		file_terreader_v1_terreader_proto_rawDescData = protoimpl.X.CompressGZIP(file_terreader_v1_terreader_proto_rawDescData)
+	})
+	return file_terreader_v1_terreader_proto_rawDescData
+}
+
+var file_terreader_v1_terreader_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_terreader_v1_terreader_proto_goTypes = []any{
+	(*Row)(nil),                   // 0: terreader.v1.Row
+	(*Document)(nil),              // 1: terreader.v1.Document
+	(*RowReadResult)(nil),         // 2: terreader.v1.RowReadResult
+	(*ReadAllRequest)(nil),        // 3: terreader.v1.ReadAllRequest
+	(*GetRequest)(nil),            // 4: terreader.v1.GetRequest
+	(*ScreenRequest)(nil),         // 5: terreader.v1.ScreenRequest
+	(*Hit)(nil),                   // 6: terreader.v1.Hit
+	(*ScreenResponse)(nil),        // 7: terreader.v1.ScreenResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_terreader_v1_terreader_proto_depIdxs = []int32{
+	8,  // 0: terreader.v1.Row.gr:type_name -> google.protobuf.Timestamp
+	8,  // 1: terreader.v1.Row.cb_date:type_name -> google.protobuf.Timestamp
+	8,  // 2: terreader.v1.Row.ce_date:type_name -> google.protobuf.Timestamp
+	1,  // 3: terreader.v1.Row.other_documents:type_name -> terreader.v1.Document
+	0,  // 4: terreader.v1.RowReadResult.row:type_name -> terreader.v1.Row
+	0,  // 5: terreader.v1.Hit.row:type_name -> terreader.v1.Row
+	6,  // 6: terreader.v1.ScreenResponse.hits:type_name -> terreader.v1.Hit
+	3,  // 7: terreader.v1.TerReaderService.ReadAll:input_type -> terreader.v1.ReadAllRequest
+	4,  // 8: terreader.v1.TerReaderService.Get:input_type -> terreader.v1.GetRequest
+	5,  // 9: terreader.v1.TerReaderService.Screen:input_type -> terreader.v1.ScreenRequest
+	2,  // 10: terreader.v1.TerReaderService.ReadAll:output_type -> terreader.v1.RowReadResult
+	0,  // 11: terreader.v1.TerReaderService.Get:output_type -> terreader.v1.Row
+	7,  // 12: terreader.v1.TerReaderService.Screen:output_type -> terreader.v1.ScreenResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_terreader_v1_terreader_proto_init() }
+func file_terreader_v1_terreader_proto_init() {
+	if File_terreader_v1_terreader_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_terreader_v1_terreader_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Row); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_terreader_v1_terreader_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Document); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_terreader_v1_terreader_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*RowReadResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_terreader_v1_terreader_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ReadAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_terreader_v1_terreader_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_terreader_v1_terreader_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ScreenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_terreader_v1_terreader_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Hit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_terreader_v1_terreader_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ScreenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_terreader_v1_terreader_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_terreader_v1_terreader_proto_goTypes,
+		DependencyIndexes: file_terreader_v1_terreader_proto_depIdxs,
+		MessageInfos:      file_terreader_v1_terreader_proto_msgTypes,
+	}.Build()
+	File_terreader_v1_terreader_proto = out.File
+	file_terreader_v1_terreader_proto_rawDesc = nil
+	file_terreader_v1_terreader_proto_goTypes = nil
+	file_terreader_v1_terreader_proto_depIdxs = nil
+}
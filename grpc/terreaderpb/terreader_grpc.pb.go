@@ -0,0 +1,240 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: terreader/v1/terreader.proto
+
+package terreaderpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	TerReaderService_ReadAll_FullMethodName = "/terreader.v1.TerReaderService/ReadAll"
+	TerReaderService_Get_FullMethodName     = "/terreader.v1.TerReaderService/Get"
+	TerReaderService_Screen_FullMethodName  = "/terreader.v1.TerReaderService/Screen"
+)
+
+// TerReaderServiceClient is the client API for TerReaderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TerReaderService provides access to records of terrorists database.
+type TerReaderServiceClient interface {
+	// ReadAll streams all records ordered by number. Records are taken from the
+	// same loaded list as Get and Screen use, so they never have errors.
+	ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (TerReaderService_ReadAllClient, error)
+	// Get returns record by number.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Row, error)
+	// Screen returns records which match name, birth date or document ranked by score.
+	Screen(ctx context.Context, in *ScreenRequest, opts ...grpc.CallOption) (*ScreenResponse, error)
+}
+
+type terReaderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTerReaderServiceClient(cc grpc.ClientConnInterface) TerReaderServiceClient {
+	return &terReaderServiceClient{cc}
+}
+
+func (c *terReaderServiceClient) ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (TerReaderService_ReadAllClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TerReaderService_ServiceDesc.Streams[0], TerReaderService_ReadAll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terReaderServiceReadAllClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TerReaderService_ReadAllClient interface {
+	Recv() (*RowReadResult, error)
+	grpc.ClientStream
+}
+
+type terReaderServiceReadAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *terReaderServiceReadAllClient) Recv() (*RowReadResult, error) {
+	m := new(RowReadResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *terReaderServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Row, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Row)
+	err := c.cc.Invoke(ctx, TerReaderService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terReaderServiceClient) Screen(ctx context.Context, in *ScreenRequest, opts ...grpc.CallOption) (*ScreenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScreenResponse)
+	err := c.cc.Invoke(ctx, TerReaderService_Screen_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TerReaderServiceServer is the server API for TerReaderService service.
+// All implementations must embed UnimplementedTerReaderServiceServer
+// for forward compatibility
+//
+// TerReaderService provides access to records of terrorists database.
+type TerReaderServiceServer interface {
+	// ReadAll streams all records ordered by number. Records are taken from the
+	// same loaded list as Get and Screen use, so they never have errors.
+	ReadAll(*ReadAllRequest, TerReaderService_ReadAllServer) error
+	// Get returns record by number.
+	Get(context.Context, *GetRequest) (*Row, error)
+	// Screen returns records which match name, birth date or document ranked by score.
+	Screen(context.Context, *ScreenRequest) (*ScreenResponse, error)
+	mustEmbedUnimplementedTerReaderServiceServer()
+}
+
+// UnimplementedTerReaderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTerReaderServiceServer struct {
+}
+
+func (UnimplementedTerReaderServiceServer) ReadAll(*ReadAllRequest, TerReaderService_ReadAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadAll not implemented")
+}
+func (UnimplementedTerReaderServiceServer) Get(context.Context, *GetRequest) (*Row, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedTerReaderServiceServer) Screen(context.Context, *ScreenRequest) (*ScreenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Screen not implemented")
+}
+func (UnimplementedTerReaderServiceServer) mustEmbedUnimplementedTerReaderServiceServer() {}
+
+// UnsafeTerReaderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TerReaderServiceServer will
+// result in compilation errors.
+type UnsafeTerReaderServiceServer interface {
+	mustEmbedUnimplementedTerReaderServiceServer()
+}
+
+func RegisterTerReaderServiceServer(s grpc.ServiceRegistrar, srv TerReaderServiceServer) {
+	s.RegisterService(&TerReaderService_ServiceDesc, srv)
+}
+
+func _TerReaderService_ReadAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerReaderServiceServer).ReadAll(m, &terReaderServiceReadAllServer{ServerStream: stream})
+}
+
+type TerReaderService_ReadAllServer interface {
+	Send(*RowReadResult) error
+	grpc.ServerStream
+}
+
+type terReaderServiceReadAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *terReaderServiceReadAllServer) Send(m *RowReadResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TerReaderService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerReaderServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerReaderService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerReaderServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerReaderService_Screen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScreenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerReaderServiceServer).Screen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerReaderService_Screen_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerReaderServiceServer).Screen(ctx, req.(*ScreenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TerReaderService_ServiceDesc is the grpc.ServiceDesc for TerReaderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TerReaderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "terreader.v1.TerReaderService",
+	HandlerType: (*TerReaderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _TerReaderService_Get_Handler,
+		},
+		{
+			MethodName: "Screen",
+			Handler:    _TerReaderService_Screen_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadAll",
+			Handler:       _TerReaderService_ReadAll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "terreader/v1/terreader.proto",
+}
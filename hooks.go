@@ -0,0 +1,72 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import "time"
+
+// Hooks structure for store functions which are called while file is read. Hooks are used for
+// instrumentation, for example by metrics package. Any function can be nil.
+// Functions are called from the goroutine which reads the file, so they must be fast.
+type Hooks struct {
+	// IndexBuilt is called after rows of the file are grouped into records. It is called only by the first read.
+	IndexBuilt func(rows int, duration time.Duration, err error)
+	// RecordBuilt is called after every record is built. Error is a *RecordError in most cases.
+	RecordBuilt func(number uint64, duration time.Duration, err error)
+	// ReadFinished is called when read is finished. Error is nil if all records were read.
+	ReadFinished func(records int, err error)
+	// ListLoaded is called after ReadFinished if all records of the file were read without errors,
+	// range and shard. LastUpdate is a date of the last update from the dbf file header,
+	// it is zero for readers which are not created from dbf file.
+	ListLoaded func(lastUpdate time.Time)
+}
+
+// WithHooks adds hooks which are called while file is read. Hooks added by several options are all called.
+func WithHooks(hooks Hooks) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.hooks = append(cfg.hooks[:len(cfg.hooks):len(cfg.hooks)], hooks)
+	}
+}
+
+func (cfg *readConfig) indexBuilt(rows int, duration time.Duration, err error) {
+	for _, h := range cfg.hooks {
+		if h.IndexBuilt != nil {
+			h.IndexBuilt(rows, duration, err)
+		}
+	}
+}
+
+func (cfg *readConfig) recordBuilt(number uint64, duration time.Duration, err error) {
+	for _, h := range cfg.hooks {
+		if h.RecordBuilt != nil {
+			h.RecordBuilt(number, duration, err)
+		}
+	}
+}
+
+func (cfg *readConfig) readFinished(records int, err error) {
+	for _, h := range cfg.hooks {
+		if h.ReadFinished != nil {
+			h.ReadFinished(records, err)
+		}
+	}
+}
+
+func (cfg *readConfig) listLoaded(lastUpdate time.Time) {
+	for _, h := range cfg.hooks {
+		if h.ListLoaded != nil {
+			h.ListLoaded(lastUpdate)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package terreader
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithHooks(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var indexedRows, finishedRecords []int
+	var numbers []uint64
+	var finishErr error
+	hooks := Hooks{
+		IndexBuilt: func(rows int, duration time.Duration, err error) {
+			if err != nil || duration < 0 {
+				t.Errorf("index hook arguments not correct, got %v and %v", duration, err)
+			}
+			indexedRows = append(indexedRows, rows)
+		},
+		RecordBuilt: func(number uint64, duration time.Duration, err error) {
+			if err != nil || duration < 0 {
+				t.Errorf("record hook arguments not correct, got %v and %v", duration, err)
+			}
+			numbers = append(numbers, number)
+		},
+		ReadFinished: func(records int, err error) {
+			finishedRecords = append(finishedRecords, records)
+			finishErr = err
+		},
+	}
+
+	results, err := tr.ReadContext(context.Background(), WithHooks(hooks), WithHooks(Hooks{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range results {
+	}
+
+	if !reflect.DeepEqual(indexedRows, []int{11}) {
+		t.Errorf("indexed rows not correct. Expected [11], got %v", indexedRows)
+	}
+	if !reflect.DeepEqual(numbers, []uint64{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("numbers not correct. Expected [1 2 3 4 5 6], got %v", numbers)
+	}
+	if !reflect.DeepEqual(finishedRecords, []int{6}) || finishErr != nil {
+		t.Errorf("finish not correct. Expected [6] and nil, got %v and %v", finishedRecords, finishErr)
+	}
+}
+
+func TestHooks_ListLoaded(t *testing.T) {
+	dbfReader, err := NewTerReader(filePath, fileEncoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tableReader, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		title  string
+		tr     *TerReader
+		opts   []ReadOption
+		etalon []time.Time
+	}{
+		{"when all records are read", dbfReader, nil, []time.Time{time.Date(2020, time.December, 27, 0, 0, 0, 0, time.UTC)}},
+		{"when reader has no dbf header", tableReader, nil, []time.Time{{}}},
+		{"when range is read", tableReader, []ReadOption{WithRange(1, 6)}, nil},
+		{"when shard is read", tableReader, []ReadOption{WithShard(0, 1)}, nil},
+		{"when read is failed", tableReader, []ReadOption{WithEnumDomain("KD", "01")}, nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			var loaded []time.Time
+			hooks := Hooks{ListLoaded: func(lastUpdate time.Time) { loaded = append(loaded, lastUpdate) }}
+
+			results, err := testCase.tr.ReadContext(context.Background(), append(testCase.opts, WithHooks(hooks))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range results {
+			}
+
+			if !reflect.DeepEqual(loaded, testCase.etalon) {
+				t.Errorf("loaded lists not correct. Expected %v, got %v", testCase.etalon, loaded)
+			}
+		})
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	rows := conflictingRows()
+	rows[0]["GR"] = "not_date"
+
+	testCases := []struct {
+		title string
+		opts  []ReadOption
+		kind  ErrorKind
+		field string
+	}{
+		{"when enum value is not allowed", []ReadOption{WithEnumDomain("KD", "01")}, ErrorKindEnum, "KD"},
+		{"when values are conflicting", []ReadOption{WithConflictPolicy(ConflictError)}, ErrorKindConflict, "TU"},
+		{"when date is not correct", nil, ErrorKindDate, "GR"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tr, err := NewTerReaderFromTable(NewMapTable(rows))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var hookErr error
+			opts := append(testCase.opts, WithHooks(Hooks{ReadFinished: func(_ int, err error) { hookErr = err }}))
+			results, err := tr.ReadContext(context.Background(), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := <-results
+			for range results {
+			}
+
+			var recordErr *RecordError
+			if !errors.As(res.Error, &recordErr) {
+				t.Fatalf("error object not correct. Expected *RecordError, got %v", res.Error)
+			}
+			if recordErr.Kind != testCase.kind || recordErr.Field != testCase.field {
+				t.Errorf("error not correct. Expected %s of %s, got %s of %s", testCase.kind, testCase.field, recordErr.Kind, recordErr.Field)
+			}
+			if hookErr != res.Error {
+				t.Errorf("error of finish hook not correct. Expected %v, got %v", res.Error, hookErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoMetadata is returned by Metadata for readers which are not created from dbf file.
+var ErrNoMetadata = errors.New("metadata is available only for dbf files")
+
+// Metadata structure for store values of dbf file header.
+// NumberOfRecords is a number of rows in the file, not a number of joined records.
+type Metadata struct {
+	LastUpdate      time.Time
+	NumberOfRecords int
+	HeaderLength    int
+	RecordLength    int
+	Fields          []FieldDescriptor
+	FileSize        int64
+	Encoding        string
+}
+
+// FieldDescriptor structure for store description of dbf file column.
+// Type is a dBase type letter, for example "C" for character and "D" for date fields.
+type FieldDescriptor struct {
+	Name         string
+	Type         string
+	Length       int
+	DecimalCount int
+}
+
+// Metadata returns values of dbf file header.
+func (tr *TerReader) Metadata() (Metadata, error) {
+	h := tr.header
+	if h == nil {
+		return Metadata{}, ErrNoMetadata
+	}
+
+	fields := make([]FieldDescriptor, 0, len(h.fields))
+	for _, f := range h.fields {
+		fields = append(fields, FieldDescriptor{
+			Name:         f.name,
+			Type:         string(f.fieldType),
+			Length:       int(f.length),
+			DecimalCount: int(f.decimals),
+		})
+	}
+
+	return Metadata{
+		LastUpdate:      h.lastUpdate(),
+		NumberOfRecords: int(h.numberOfRecords),
+		HeaderLength:    int(h.headerLength),
+		RecordLength:    int(h.recordLength),
+		Fields:          fields,
+		FileSize:        tr.fileSize,
+		Encoding:        tr.encoding,
+	}, nil
+}
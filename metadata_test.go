@@ -0,0 +1,97 @@
+package terreader
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTerReader_Metadata(t *testing.T) {
+	t.Run("when reader created from dbf file", func(t *testing.T) {
+		tr, err := NewTerReader(filePath, fileEncoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := tr.Metadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !m.LastUpdate.Equal(time.Date(2020, time.December, 27, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("last update not correct. Got %v", m.LastUpdate)
+		}
+		if m.NumberOfRecords != 2 || m.HeaderLength != 769 || m.RecordLength != 2227 {
+			t.Errorf("header values not correct. Got %+v", m)
+		}
+		if m.FileSize != 5224 || m.Encoding != fileEncoding {
+			t.Errorf("file size or encoding not correct. Got %d and %s", m.FileSize, m.Encoding)
+		}
+		if len(m.Fields) != 23 {
+			t.Fatalf("num of fields not correct. Expected 23, got %d", len(m.Fields))
+		}
+
+		etalonFields := []FieldDescriptor{
+			{Name: "NUMBER", Type: "C", Length: 5},
+			{Name: "TERROR", Type: "C", Length: 1},
+			{Name: "TU", Type: "N", Length: 1},
+		}
+		if !reflect.DeepEqual(m.Fields[:3], etalonFields) {
+			t.Errorf("fields not correct. Expected %+v, got %+v", etalonFields, m.Fields[:3])
+		}
+		if gr := m.Fields[14]; gr.Name != "GR" || gr.Type != "D" || gr.Length != 8 {
+			t.Errorf("field GR not correct. Got %+v", gr)
+		}
+	})
+
+	t.Run("when header of dbf file is not correct", func(t *testing.T) {
+		data := readTestFile(t)
+		// Record length is less than length of fields, go-dbf does not check it.
+		binary.LittleEndian.PutUint16(data[10:12], 2226)
+
+		tr, err := NewTerReaderFromByteSlice(data, fileEncoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tr.Metadata(); err != ErrNoMetadata {
+			t.Errorf("error object not correct. Expected %v, got %v", ErrNoMetadata, err)
+		}
+
+		data = readTestFile(t)
+		binary.LittleEndian.PutUint16(data[10:12], 2226)
+		etalon := "dbf record length '2226' is less than length of fields '2227'"
+		if _, err := NewTerReaderFromByteSlice(data, EncodingAuto); err == nil || err.Error() != etalon {
+			t.Errorf("error message not correct. Expected \"%s\", got %v", etalon, err)
+		}
+	})
+
+	t.Run("when reader created from table", func(t *testing.T) {
+		tr, err := NewTerReaderFromTable(NewMapTable(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tr.Metadata(); err != ErrNoMetadata {
+			t.Errorf("error object not correct. Expected %v, got %v", ErrNoMetadata, err)
+		}
+	})
+}
+
+func Test_dbfHeader_lastUpdate(t *testing.T) {
+	testCases := []struct {
+		year byte
+		date time.Time
+	}{
+		{121, time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{21, time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{95, time.Date(1995, time.June, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range testCases {
+		h := dbfHeader{updateYear: testCase.year, updateMonth: 6, updateDay: 1}
+		if d := h.lastUpdate(); !d.Equal(testCase.date) {
+			t.Errorf("date for year byte %d not correct. Expected %v, got %v", testCase.year, testCase.date, d)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides Prometheus metrics for reading of terrorists database and screening.
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/will-evil/terreader"
+	"github.com/will-evil/terreader/screen"
+)
+
+const (
+	namespace = "terreader"
+	// errorKindOther is a kind of build errors which are not *terreader.RecordError.
+	errorKindOther = "other"
+)
+
+// Metrics structure for store collectors of the package.
+type Metrics struct {
+	rowsIndexed    prometheus.Counter
+	recordsBuilt   prometheus.Counter
+	buildErrors    *prometheus.CounterVec
+	indexDuration  prometheus.Histogram
+	recordDuration prometheus.Histogram
+	lastLoad       prometheus.Gauge
+	lastUpdate     prometheus.Gauge
+	screenDuration prometheus.Histogram
+	screenHits     prometheus.Histogram
+}
+
+// New is a constructor for Metrics structure. Collectors are registered with provided registerer.
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		rowsIndexed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rows_indexed_total",
+			Help:      "Number of rows of the file which were indexed.",
+		}),
+		recordsBuilt: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "records_built_total",
+			Help:      "Number of records which were built without errors.",
+		}),
+		buildErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "build_errors_total",
+			Help:      "Number of errors of building of records by kind.",
+		}, []string{"kind"}),
+		indexDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "index_duration_seconds",
+			Help:      "Time spent on indexing of rows of the file.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+		}),
+		recordDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "record_build_duration_seconds",
+			Help:      "Time spent on building of a record.",
+			Buckets:   prometheus.ExponentialBuckets(0.000001, 4, 10),
+		}),
+		lastLoad: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_successful_load_timestamp_seconds",
+			Help:      "Unix time of the last read of all records of the file without range, shard and errors.",
+		}),
+		lastUpdate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "list_last_update_timestamp_seconds",
+			Help:      "Date of the last update from the dbf header of the list which was loaded the last.",
+		}),
+		screenDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "screen_duration_seconds",
+			Help:      "Time spent on screening of a query.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		}),
+		screenHits: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "screen_hits",
+			Help:      "Number of records found by screening of a query.",
+			Buckets:   []float64{0, 1, 2, 5, 10, 20, 50},
+		}),
+	}
+
+	collectors := []prometheus.Collector{
+		m.rowsIndexed, m.recordsBuilt, m.buildErrors, m.indexDuration,
+		m.recordDuration, m.lastLoad, m.lastUpdate, m.screenDuration, m.screenHits,
+	}
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Hooks returns hooks of reader which update metrics.
+func (m *Metrics) Hooks() terreader.Hooks {
+	return terreader.Hooks{
+		IndexBuilt: func(rows int, duration time.Duration, _ error) {
+			m.rowsIndexed.Add(float64(rows))
+			m.indexDuration.Observe(duration.Seconds())
+		},
+		RecordBuilt: func(_ uint64, duration time.Duration, err error) {
+			m.recordDuration.Observe(duration.Seconds())
+			if err != nil {
+				m.buildErrors.WithLabelValues(errorKind(err)).Inc()
+				return
+			}
+			m.recordsBuilt.Inc()
+		},
+		ListLoaded: m.listLoaded,
+	}
+}
+
+// ReadOption returns option of reader which updates metrics. It can be passed to terreader.Open or ReadContext.
+func (m *Metrics) ReadOption() terreader.ReadOption {
+	return terreader.WithHooks(m.Hooks())
+}
+
+// Screen screens the query by index and updates metrics of screening.
+func (m *Metrics) Screen(idx *screen.Index, q screen.Query, limit int) []screen.Hit {
+	start := time.Now()
+	hits := idx.Screen(q, limit)
+	m.ObserveScreen(time.Since(start), len(hits))
+
+	return hits
+}
+
+// ObserveScreen updates metrics of screening which is done by caller.
+func (m *Metrics) ObserveScreen(duration time.Duration, hits int) {
+	m.screenDuration.Observe(duration.Seconds())
+	m.screenHits.Observe(float64(hits))
+}
+
+// listLoaded updates time of the last load and date of the last update of the list.
+// Date is unknown for readers which are not created from dbf file, then it is not changed.
+func (m *Metrics) listLoaded(lastUpdate time.Time) {
+	m.lastLoad.SetToCurrentTime()
+	if !lastUpdate.IsZero() {
+		m.lastUpdate.Set(float64(lastUpdate.Unix()))
+	}
+}
+
+func errorKind(err error) string {
+	var recordErr *terreader.RecordError
+	if errors.As(err, &recordErr) {
+		return string(recordErr.Kind)
+	}
+
+	return errorKindOther
+}
@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/will-evil/terreader"
+	"github.com/will-evil/terreader/screen"
+)
+
+const fileEncoding = "866"
+const filePath = "../test/data/testfile.dbf"
+
+func readAll(t *testing.T, tr *terreader.TerReader, m *Metrics) []terreader.RowReadResult {
+	results, err := tr.ReadContext(context.Background(), m.ReadOption())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var all []terreader.RowReadResult
+	for res := range results {
+		all = append(all, res)
+	}
+
+	return all
+}
+
+func TestMetrics_ReadOption(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := New(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr, err := terreader.NewTerReader(filePath, fileEncoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Close()
+
+	before := time.Now().Unix()
+	readAll(t, tr, m)
+
+	if res := testutil.ToFloat64(m.rowsIndexed); res != 2 {
+		t.Errorf("rows indexed not correct. Expected 2, got %v", res)
+	}
+	if res := testutil.ToFloat64(m.recordsBuilt); res != 1 {
+		t.Errorf("records built not correct. Expected 1, got %v", res)
+	}
+	if res := testutil.CollectAndCount(m.buildErrors); res != 0 {
+		t.Errorf("build errors not correct. Expected 0 series, got %d", res)
+	}
+	if res := testutil.ToFloat64(m.lastLoad); res < float64(before) {
+		t.Errorf("last successful load timestamp not correct. Expected at least %d, got %v", before, res)
+	}
+
+	lastUpdate := time.Date(2020, time.December, 27, 0, 0, 0, 0, time.UTC).Unix()
+	if res := testutil.ToFloat64(m.lastUpdate); res != float64(lastUpdate) {
+		t.Errorf("list last update timestamp not correct. Expected %d, got %v", lastUpdate, res)
+	}
+
+	// Part of the list is read, so the list is not loaded.
+	m.lastLoad.Set(0)
+	results, err := tr.ReadContext(context.Background(), m.ReadOption(), terreader.WithRange(1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range results {
+	}
+	if res := testutil.ToFloat64(m.lastLoad); res != 0 {
+		t.Errorf("last successful load timestamp not correct. Expected 0, got %v", res)
+	}
+	if res := testutil.ToFloat64(m.lastUpdate); res != float64(lastUpdate) {
+		t.Errorf("list last update timestamp not correct. Expected %d, got %v", lastUpdate, res)
+	}
+
+	count, err := testutil.GatherAndCount(reg,
+		"terreader_index_duration_seconds", "terreader_record_build_duration_seconds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("number of duration histograms not correct. Expected 2, got %d", count)
+	}
+}
+
+func TestMetrics_buildErrors(t *testing.T) {
+	m, err := New(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := terreader.NewMapTable([]map[string]string{{"NUMBER": "1", "ROW_ID": "1"}})
+	tr, err := terreader.NewTerReaderFromTable(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := readAll(t, tr, m)
+	if len(results) != 1 || results[0].Error == nil {
+		t.Fatalf("results not correct. Expected one result with error, got %+v", results)
+	}
+
+	if res := testutil.ToFloat64(m.buildErrors.WithLabelValues(string(terreader.ErrorKindField))); res != 1 {
+		t.Errorf("build errors not correct. Expected 1, got %v", res)
+	}
+	if res := testutil.ToFloat64(m.recordsBuilt); res != 0 {
+		t.Errorf("records built not correct. Expected 0, got %v", res)
+	}
+	if res := testutil.ToFloat64(m.lastLoad); res != 0 {
+		t.Errorf("last successful load timestamp not correct. Expected 0, got %v", res)
+	}
+}
+
+func TestMetrics_Screen(t *testing.T) {
+	m, err := New(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx := screen.NewIndex([]terreader.Row{{Number: "1", Nameu: "ИВАНОВ ИВАН"}})
+	hits := m.Screen(idx, screen.Query{Name: "иванов"}, 0)
+	if len(hits) != 1 {
+		t.Errorf("hits not correct. Expected 1 hit, got %+v", hits)
+	}
+
+	if res := testutil.CollectAndCount(m.screenHits); res != 1 {
+		t.Errorf("screen hits not correct. Expected 1 series, got %d", res)
+	}
+}
+
+func TestNew(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := New(reg); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := New(reg); err == nil {
+		t.Error("error object not correct. Expected error of duplicate registration, got nil")
+	}
+}
+
+func Test_errorKind(t *testing.T) {
+	if res := errorKind(&terreader.RecordError{Kind: terreader.ErrorKindEnum}); res != "enum" {
+		t.Errorf("kind not correct. Expected \"enum\", got \"%s\"", res)
+	}
+	if res := errorKind(context.Canceled); res != errorKindOther {
+		t.Errorf("kind not correct. Expected \"%s\", got \"%s\"", errorKindOther, res)
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// EnumPolicy says what to do with enum fields which have no allowed value. Policies can be combined.
+type EnumPolicy int
+
+// Policies of enum fields.
+const (
+	// EnumStrict makes Read return error if enum field has no allowed value. It is the default policy.
+	EnumStrict EnumPolicy = 0
+	// EnumAllowEmpty allows enum fields which are empty in all rows of record.
+	EnumAllowEmpty EnumPolicy = 1 << iota
+	// EnumAllowUnknown allows values which are not in the enum domain, they are reported
+	// by DiagnosticUnknownEnumValue.
+	EnumAllowUnknown
+)
+
+// openConfig structure for store options of Open.
+type openConfig struct {
+	encoding           string
+	includeDeletedRows bool
+	read               readConfig
+}
+
+// readConfig structure for store options which are used while records are read.
+type readConfig struct {
+	chanBuff             uint
+	allowEmptyEnumValues bool
+	allowUnknownEnums    bool
+	strict               bool
+	conflictPolicy       ConflictPolicy
+	enums                map[string][]string
+	dateLayouts          []string
+	progress             ProgressFunc
+	hooks                []Hooks
+	logger               *slog.Logger
+	limited              bool
+	from                 uint64
+	to                   uint64
+	sharded              bool
+	shardIndex           int
+	shardTotal           int
+}
+
+// Option configures reader created by Open. ReadOption is an Option too, then it is used by every read.
+type Option interface {
+	applyOpen(cfg *openConfig)
+}
+
+type openOption func(cfg *openConfig)
+
+func (o openOption) applyOpen(cfg *openConfig) {
+	o(cfg)
+}
+
+// ReadOption configures reading of records. It can be passed to ReadContext or to Open.
+type ReadOption func(cfg *readConfig)
+
+func (o ReadOption) applyOpen(cfg *openConfig) {
+	o(&cfg.read)
+}
+
+// Open is a TerReader constructor with options. Encoding is detected by content of the file
+// if WithEncoding option is not set.
+func Open(filePath string, opts ...Option) (*TerReader, error) {
+	cfg := openConfig{encoding: EncodingAuto}
+	for _, opt := range opts {
+		opt.applyOpen(&cfg)
+	}
+
+	tr, err := NewTerReader(filePath, cfg.encoding)
+	if err != nil {
+		return nil, err
+	}
+	tr.includeDeletedRows = cfg.includeDeletedRows
+	tr.config = cfg.read
+
+	return tr, nil
+}
+
+// WithEncoding sets encoding of the file.
+func WithEncoding(encoding string) Option {
+	return openOption(func(cfg *openConfig) {
+		cfg.encoding = encoding
+	})
+}
+
+// WithIncludeDeletedRows makes reader join rows marked as deleted into records, see TerReader.IncludeDeletedRows.
+func WithIncludeDeletedRows() Option {
+	return openOption(func(cfg *openConfig) {
+		cfg.includeDeletedRows = true
+	})
+}
+
+// WithBuffer sets number of records which can be buffered in the chan with results. Zero size works like 1.
+// The chan has one more slot, which is reserved for the reason of stopping of reading.
+func WithBuffer(size uint) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.chanBuff = size
+	}
+}
+
+// WithProgress sets function which is called with progress of indexing and reading.
+// Indexing is reported only by the first read, which builds the index.
+func WithProgress(fn ProgressFunc) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.progress = fn
+	}
+}
+
+// WithStrict enables strict mode, see TerReader.Strict.
+func WithStrict() ReadOption {
+	return func(cfg *readConfig) {
+		cfg.strict = true
+	}
+}
+
+// WithConflictPolicy sets policy which chooses value if rows of record have different values,
+// see TerReader.WithConflictPolicy.
+func WithConflictPolicy(policy ConflictPolicy) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.conflictPolicy = policy
+	}
+}
+
+// WithDateLayouts sets layouts of date fields. Layouts are tried in provided order.
+// Default layout is "20060102".
+func WithDateLayouts(layouts ...string) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.dateLayouts = append([]string{}, layouts...)
+	}
+}
+
+// WithEnumPolicy sets policy of enum fields.
+func WithEnumPolicy(policy EnumPolicy) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.allowEmptyEnumValues = policy&EnumAllowEmpty != 0
+		cfg.allowUnknownEnums = policy&EnumAllowUnknown != 0
+	}
+}
+
+// WithEnumDomain sets allowed values of enum field, see TerReader.RegisterEnum.
+func WithEnumDomain(fieldName string, values ...string) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.setEnum(fieldName, values)
+	}
+}
+
+// WithEnumValues adds values to allowed values of enum field, see TerReader.ExtendEnum.
+func WithEnumValues(fieldName string, values ...string) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.extendEnum(fieldName, values)
+	}
+}
+
+// WithLogger sets logger for decisions which are made while records are built: skipped deleted rows,
+// skipped duplicate parts of text, empty and not allowed values of enum fields. Nothing is logged by default.
+// Deleted rows are skipped while rows are indexed, which is done only by the first read,
+// so they are logged only by logger of the first read.
+func WithLogger(logger *slog.Logger) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.logger = logger
+	}
+}
+
+// setEnum sets allowed values of enum field. Map of enums is copied, because it can be shared
+// with the config of the reader.
+func (cfg *readConfig) setEnum(fieldName string, values []string) {
+	enums := make(map[string][]string, len(cfg.enums)+1)
+	for name, v := range cfg.enums {
+		enums[name] = v
+	}
+	enums[fieldName] = append([]string{}, values...)
+
+	cfg.enums = enums
+}
+
+// extendEnum adds values to allowed values of enum field. Field without default values gets only provided values.
+func (cfg *readConfig) extendEnum(fieldName string, values []string) {
+	current, _ := cfg.enumValues(fieldName)
+
+	cfg.setEnum(fieldName, append(append([]string{}, current...), values...))
+}
+
+// enumValues returns allowed values of enum field. Registered values are used instead of default ones.
+func (cfg *readConfig) enumValues(fieldName string) ([]string, error) {
+	if values, ok := cfg.enums[fieldName]; ok {
+		return values, nil
+	}
+
+	return getEnum(fieldName)
+}
+
+// resolveConflict chooses value of field by conflict policy. Diagnostics about conflicts are returned
+// only in strict mode.
+func (cfg *readConfig) resolveConflict(fieldName string, values []fieldValue) (string, []Diagnostic, error) {
+	val, diagnostics, err := resolveConflict(cfg.conflictPolicy, fieldName, values)
+	if !cfg.strict {
+		diagnostics = nil
+	}
+
+	return val, diagnostics, err
+}
+
+// parseDate parses value of date field by configured layouts. Error of the first layout is returned
+// if value does not match any of them.
+func (cfg *readConfig) parseDate(val string) (time.Time, error) {
+	layouts := cfg.dateLayouts
+	if len(layouts) == 0 {
+		layouts = []string{dateFormat}
+	}
+
+	var firstErr error
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, val)
+		if err == nil {
+			return t, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return time.Time{}, firstErr
+}
+
+// forRecord returns config for building of record with provided number. Messages of its logger
+// contain the number.
+func (cfg *readConfig) forRecord(number uint64) *readConfig {
+	if cfg.logger == nil {
+		return cfg
+	}
+
+	recordCfg := *cfg
+	recordCfg.logger = cfg.logger.With("number", number)
+
+	return &recordCfg
+}
+
+// log writes message by logger of the config. Config without logger and nil config are allowed.
+func (cfg *readConfig) log(level slog.Level, msg string, args ...any) {
+	if cfg == nil || cfg.logger == nil {
+		return
+	}
+
+	cfg.logger.Log(context.Background(), level, msg, args...)
+}
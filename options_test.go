@@ -0,0 +1,179 @@
+package terreader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpen(t *testing.T) {
+	tr, err := Open(filePath, WithIncludeDeletedRows(), WithStrict(), WithEnumPolicy(EnumAllowEmpty))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tr.DetectedEncoding() != EncodingCP866 {
+		t.Errorf("encoding not correct. Expected '%s', got '%s'", EncodingCP866, tr.DetectedEncoding())
+	}
+	if !tr.includeDeletedRows || !tr.config.strict || !tr.config.allowEmptyEnumValues {
+		t.Errorf("options not correct, got %+v, include deleted rows %v", tr.config, tr.includeDeletedRows)
+	}
+
+	if _, err := Open("not/exists/file.dbf", WithEncoding(EncodingCP866)); err == nil {
+		t.Error("error object not correct. Expected error, got nil")
+	}
+}
+
+func TestTerReader_ReadContext(t *testing.T) {
+	rows := conflictingRows()
+	rows[0]["GR"] = "2020-08-21"
+
+	tr, err := NewTerReaderFromTable(NewMapTable(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.RegisterEnum("KD", "01")
+
+	testCases := []struct {
+		title string
+		opts  []ReadOption
+		tu    string
+		gr    *time.Time
+		err   error
+	}{
+		{"when options are not set", nil, "", nil, errors.New("can not find a suitable value for 'KD'")},
+		{
+			"when options are set",
+			[]ReadOption{WithEnumValues("KD", "04"), WithConflictPolicy(ConflictLast), WithDateLayouts(dateFormat, "2006-01-02")},
+			"2",
+			timePtr(time.Date(2020, time.August, 21, 0, 0, 0, 0, time.UTC)),
+			nil,
+		},
+		{
+			"when date layout is not set",
+			[]ReadOption{WithEnumDomain("KD", "04")},
+			"",
+			nil,
+			errors.New(`parsing time "2020-08-21" as "20060102": cannot parse "-08-21" as "01"`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			results, err := tr.ReadContext(context.Background(), testCase.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res := <-results
+			if testCase.err != nil {
+				if res.Error == nil {
+					t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+				} else if res.Error.Error() != testCase.err.Error() {
+					t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), res.Error.Error())
+				}
+				return
+			}
+			if res.Error != nil {
+				t.Fatal(res.Error)
+			}
+
+			if res.Row.Tu != testCase.tu {
+				t.Errorf("TU not correct. Expected '%s', got '%s'", testCase.tu, res.Row.Tu)
+			}
+			if !reflect.DeepEqual(res.Row.Gr, testCase.gr) {
+				t.Errorf("GR not correct. Expected %v, got %v", testCase.gr, res.Row.Gr)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(tr.config.enums, map[string][]string{"KD": {"01"}}) {
+		t.Errorf("options of reader are changed by read options, got %v", tr.config.enums)
+	}
+}
+
+func TestWithEnumPolicy(t *testing.T) {
+	testCases := []struct {
+		policy       EnumPolicy
+		allowEmpty   bool
+		allowUnknown bool
+	}{
+		{EnumStrict, false, false},
+		{EnumAllowEmpty, true, false},
+		{EnumAllowUnknown, false, true},
+		{EnumAllowEmpty | EnumAllowUnknown, true, true},
+	}
+
+	for _, testCase := range testCases {
+		cfg := readConfig{allowEmptyEnumValues: true, allowUnknownEnums: true}
+		WithEnumPolicy(testCase.policy)(&cfg)
+
+		if cfg.allowEmptyEnumValues != testCase.allowEmpty || cfg.allowUnknownEnums != testCase.allowUnknown {
+			t.Errorf("options for policy %d not correct. Expected %v and %v, got %v and %v", testCase.policy,
+				testCase.allowEmpty, testCase.allowUnknown, cfg.allowEmptyEnumValues, cfg.allowUnknownEnums)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestWithLogger(t *testing.T) {
+	newRow := func(rowID, tu, nameu string) map[string]string {
+		row := map[string]string{"NUMBER": "1", "TERROR": "1", "TU": tu, "NAMEU": nameu, "KD": "", "ROW_ID": rowID}
+		for _, field := range []string{"DESCRIPT", "KODCR", "KODCN", "AMR", "ADRESS", "SD", "RG", "ND", "VD", "GR", "YR", "MR", "CB_DATE", "CE_DATE", "DIRECTOR", "FOUNDER", "TERRTYPE"} {
+			row[field] = ""
+		}
+
+		return row
+	}
+	rows := []map[string]string{
+		newRow("1", "1", "Stale alias"),
+		newRow("2", "9", "Actual name"),
+		newRow("3", "1", "Actual name"),
+	}
+	table := deletedRowsMapTable{MapTable: NewMapTable(rows), deleted: map[int]bool{0: true}}
+	tr, err := NewTerReaderFromTable(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	results, err := tr.ReadContext(context.Background(),
+		WithLogger(logger), WithEnumPolicy(EnumAllowEmpty|EnumAllowUnknown))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for res := range results {
+		if res.Error != nil {
+			t.Fatal(res.Error)
+		}
+	}
+
+	etalon := []string{
+		`level=DEBUG msg="deleted row is skipped" number=1 index=0`,
+		`level=WARN msg="value of enum field is not allowed and it is used" number=1 column=TU index=1 value=9`,
+		`level=DEBUG msg="duplicate part of text is skipped" number=1 column=NAMEU index=2`,
+		`level=DEBUG msg="enum field has no allowed value and it is left empty" number=1 column=KD`,
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !reflect.DeepEqual(lines, etalon) {
+		t.Errorf("log not correct. Expected %q, got %q", etalon, lines)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import "time"
+
+// ProgressStage is a stage of reading which progress is reported.
+type ProgressStage string
+
+// Stages of reading.
+const (
+	// ProgressIndexing is reported while rows of the file are grouped into records before the first read.
+	ProgressIndexing ProgressStage = "indexing"
+	// ProgressReading is reported while records are sent to the chan.
+	ProgressReading ProgressStage = "reading"
+)
+
+// progressSteps is a number of reports for a stage, progress is reported every 1 percent.
+const progressSteps = 100
+
+// Progress structure for store progress of the stage. Done and Total are numbers of rows for indexing
+// and numbers of records for reading. Throughput is a number of rows or records per second.
+type Progress struct {
+	Stage      ProgressStage
+	Done       int
+	Total      int
+	Elapsed    time.Duration
+	Throughput float64
+	ETA        time.Duration
+}
+
+// ProgressFunc is called with progress of indexing and reading. It is called from the goroutine
+// which reads the file, so it must be fast.
+type ProgressFunc func(p Progress)
+
+// progressTracker structure for reporting progress of the stage. Nil tracker reports nothing.
+type progressTracker struct {
+	fn    ProgressFunc
+	stage ProgressStage
+	total int
+	step  int
+	start time.Time
+}
+
+func newProgressTracker(fn ProgressFunc, stage ProgressStage, total int) *progressTracker {
+	if fn == nil {
+		return nil
+	}
+
+	step := total / progressSteps
+	if step < 1 {
+		step = 1
+	}
+
+	return &progressTracker{fn: fn, stage: stage, total: total, step: step, start: time.Now()}
+}
+
+// report calls progress function if done is a multiple of step or the stage is finished.
+func (p *progressTracker) report(done int) {
+	if p == nil || (done%p.step != 0 && done != p.total) {
+		return
+	}
+
+	progress := Progress{Stage: p.stage, Done: done, Total: p.total, Elapsed: time.Since(p.start)}
+	if seconds := progress.Elapsed.Seconds(); seconds > 0 && done > 0 {
+		progress.Throughput = float64(done) / seconds
+		progress.ETA = time.Duration(float64(p.total-done) / progress.Throughput * float64(time.Second))
+	}
+
+	p.fn(progress)
+}
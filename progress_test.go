@@ -0,0 +1,82 @@
+package terreader
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestTerReader_OnProgress(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stages []ProgressStage
+	var done []int
+	tr.OnProgress(func(p Progress) {
+		stages = append(stages, p.Stage)
+		done = append(done, p.Done)
+
+		if p.Total == 0 || p.Done > p.Total || p.Elapsed < 0 || p.ETA < 0 {
+			t.Errorf("progress not correct, got %+v", p)
+		}
+	})
+
+	results, err := tr.Read(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range results {
+	}
+
+	etalonStages := []ProgressStage{
+		ProgressIndexing, ProgressIndexing, ProgressIndexing, ProgressIndexing, ProgressIndexing, ProgressIndexing,
+		ProgressIndexing, ProgressIndexing, ProgressIndexing, ProgressIndexing, ProgressIndexing, ProgressIndexing,
+		ProgressReading, ProgressReading, ProgressReading, ProgressReading, ProgressReading, ProgressReading,
+	}
+	if !reflect.DeepEqual(stages, etalonStages) {
+		t.Errorf("stages not correct. Expected %v, got %v", etalonStages, stages)
+	}
+	etalonDone := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 1, 2, 3, 4, 5, 6}
+	if !reflect.DeepEqual(done, etalonDone) {
+		t.Errorf("done not correct. Expected %v, got %v", etalonDone, done)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.setHelpData(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var last Progress
+	results, err := tr.ReadContext(context.Background(), WithProgress(func(p Progress) { last = p }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range results {
+	}
+
+	if last.Stage != ProgressReading || last.Done != 6 || last.Total != 6 || last.ETA != 0 {
+		t.Errorf("last progress not correct, got %+v", last)
+	}
+}
+
+func Test_progressTracker_report(t *testing.T) {
+	var done []int
+	p := newProgressTracker(func(p Progress) { done = append(done, p.Done) }, ProgressReading, 250)
+	for i := 1; i <= 250; i++ {
+		p.report(i)
+	}
+
+	if len(done) != 125 || done[0] != 2 || done[len(done)-1] != 250 {
+		t.Errorf("reports not correct, got %d reports: %v", len(done), done)
+	}
+
+	var nilTracker *progressTracker
+	nilTracker.report(1)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+// ErrorKind is a kind of error which is occurred while record is built.
+type ErrorKind string
+
+// Kinds of errors.
+const (
+	// ErrorKindField is an error of reading of field from the table, for example field does not exist.
+	ErrorKindField ErrorKind = "field"
+	// ErrorKindEnum is returned if enum field has no allowed value or it is not supported.
+	ErrorKindEnum ErrorKind = "enum"
+	// ErrorKindConflict is returned if rows have different values and ConflictError policy is used.
+	ErrorKindConflict ErrorKind = "conflict"
+	// ErrorKindDate is returned if value of date field can not be parsed.
+	ErrorKindDate ErrorKind = "date"
+)
+
+// RecordError structure for store error which is occurred while record is built.
+// Message of the error is a message of the wrapped error.
+type RecordError struct {
+	Kind  ErrorKind
+	Field string
+	Err   error
+}
+
+func newRecordError(kind ErrorKind, field string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &RecordError{Kind: kind, Field: field, Err: err}
+}
+
+// Error returns message of the wrapped error.
+func (e *RecordError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
@@ -41,4 +41,39 @@ type Row struct {
 	Founder  string     `tr_col:"FOUNDER"  tr_type:"text"`
 	RowID    string     `tr_col:"ROW_ID"   tr_type:"static"`
 	Terrtype string     `tr_col:"TERRTYPE" tr_type:"text"`
+	// OtherDocuments are documents of the record which differ from the one in Kd, Sd, Nd and Vd.
+	// They are stored in rows of the record with different values of these fields.
+	OtherDocuments []Document `tr_type:"documents"`
+}
+
+// Document is struct for store document from KD, SD, ND and VD fields of row.
+type Document struct {
+	Kd string
+	Sd string
+	Nd string
+	Vd string
+}
+
+// documentColumns are names of fields of Document in dbf file.
+var documentColumns = []string{"KD", "SD", "ND", "VD"}
+
+// value returns value of the document for dbf field with provided name.
+func (d Document) value(column string) string {
+	switch column {
+	case "KD":
+		return d.Kd
+	case "SD":
+		return d.Sd
+	case "ND":
+		return d.Nd
+	case "VD":
+		return d.Vd
+	}
+
+	return ""
+}
+
+// isEmpty says whether the document has no values. KD "0" means that subject has no documents.
+func (d Document) isEmpty() bool {
+	return (d.Kd == "" || d.Kd == "0") && d.Sd == "" && d.Nd == "" && d.Vd == ""
 }
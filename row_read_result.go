@@ -15,8 +15,12 @@
 package terreader
 
 // RowReadResult structure for store result of row reading.
+// Deleted is set if record includes rows marked as deleted, it is possible only with IncludeDeletedRows option.
+// Diagnostics reports questionable data which was found while record was built.
 type RowReadResult struct {
-	Row    *Row
-	Number uint64
-	Error  error
+	Row         *Row
+	Number      uint64
+	Error       error
+	Deleted     bool
+	Diagnostics []Diagnostic
 }
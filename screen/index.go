@@ -0,0 +1,292 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package screen provides in-memory index for screening names, birth dates and documents
+// against records of terrorists database.
+package screen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/will-evil/terreader"
+)
+
+const (
+	nameWeight      = 2
+	birthDateWeight = 1
+	documentWeight  = 2
+)
+
+// Values of Hit.Matched which describe matched parts of the query.
+const (
+	MatchedName      = "name"
+	MatchedBirthDate = "birth_date"
+	MatchedBirthYear = "birth_year"
+	MatchedDocument  = "document"
+)
+
+// Query structure for store screening parameters. Empty fields are not used for matching.
+type Query struct {
+	Name      string
+	BirthDate *time.Time
+	Document  string
+}
+
+// Hit structure for store record which matches a query.
+// Score is in range (0, 1], the higher score the better match.
+type Hit struct {
+	Row     terreader.Row
+	Score   float64
+	Matched []string
+}
+
+// Index structure that provides functionality for searching records. Index is immutable and safe for concurrent use.
+type Index struct {
+	rows       []terreader.Row
+	byNumber   map[string]int
+	byToken    map[string][]int
+	byDocument map[string][]int
+	tokens     [][]string
+}
+
+// NewIndex is a constructor for Index structure.
+func NewIndex(rows []terreader.Row) *Index {
+	idx := &Index{
+		rows:       rows,
+		byNumber:   make(map[string]int, len(rows)),
+		byToken:    make(map[string][]int),
+		byDocument: make(map[string][]int),
+		tokens:     make([][]string, len(rows)),
+	}
+
+	for i, row := range rows {
+		idx.byNumber[row.Number] = i
+
+		idx.tokens[i] = uniqueTokens(row.Nameu)
+		for _, token := range idx.tokens[i] {
+			idx.byToken[token] = append(idx.byToken[token], i)
+		}
+
+		for _, doc := range documents(row) {
+			idx.byDocument[doc] = append(idx.byDocument[doc], i)
+		}
+	}
+
+	return idx
+}
+
+// Load reads all records from provided reader and builds Index.
+func Load(tr *terreader.TerReader) (*Index, error) {
+	results, err := tr.Read(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []terreader.Row
+	for res := range results {
+		if res.Error != nil {
+			return nil, fmt.Errorf("error for record with number '%d': %w", res.Number, res.Error)
+		}
+		rows = append(rows, *res.Row)
+	}
+
+	return NewIndex(rows), nil
+}
+
+// Len returns number of records in index.
+func (idx *Index) Len() int {
+	return len(idx.rows)
+}
+
+// Rows returns copy of records of index in order in which they were provided.
+func (idx *Index) Rows() []terreader.Row {
+	rows := make([]terreader.Row, len(idx.rows))
+	copy(rows, idx.rows)
+
+	return rows
+}
+
+// Get returns record by value of NUMBER column.
+func (idx *Index) Get(number string) (terreader.Row, bool) {
+	i, ok := idx.byNumber[number]
+	if !ok {
+		return terreader.Row{}, false
+	}
+
+	return idx.rows[i], true
+}
+
+// Screen returns records which match the query ordered by score descending.
+// Record is a candidate only if its name or document matches. Zero limit means no limit.
+func (idx *Index) Screen(q Query, limit int) []Hit {
+	queryTokens := uniqueTokens(q.Name)
+	queryDoc := normalizeDocument(q.Document)
+
+	candidates := make(map[int]struct{})
+	for _, token := range queryTokens {
+		for _, i := range idx.byToken[token] {
+			candidates[i] = struct{}{}
+		}
+	}
+	if queryDoc != "" {
+		for _, i := range idx.byDocument[queryDoc] {
+			candidates[i] = struct{}{}
+		}
+	}
+
+	maxScore := 0
+	if len(queryTokens) > 0 {
+		maxScore += nameWeight
+	}
+	if q.BirthDate != nil {
+		maxScore += birthDateWeight
+	}
+	if queryDoc != "" {
+		maxScore += documentWeight
+	}
+
+	hits := make([]Hit, 0, len(candidates))
+	for i := range candidates {
+		row := idx.rows[i]
+		var score float64
+		var matched []string
+
+		if len(queryTokens) > 0 {
+			if ratio := tokensRatio(queryTokens, idx.tokens[i]); ratio > 0 {
+				score += nameWeight * ratio
+				matched = append(matched, MatchedName)
+			}
+		}
+
+		if q.BirthDate != nil {
+			switch {
+			case row.Gr != nil && sameDate(*row.Gr, *q.BirthDate):
+				score += birthDateWeight
+				matched = append(matched, MatchedBirthDate)
+			case birthYear(row) == q.BirthDate.Year():
+				score += birthDateWeight / 2.0
+				matched = append(matched, MatchedBirthYear)
+			}
+		}
+
+		if queryDoc != "" && includes(documents(row), queryDoc) {
+			score += documentWeight
+			matched = append(matched, MatchedDocument)
+		}
+
+		hits = append(hits, Hit{Row: row, Score: score / float64(maxScore), Matched: matched})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return idx.byNumber[hits[i].Row.Number] < idx.byNumber[hits[j].Row.Number]
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
+
+// tokensRatio returns part of query tokens which are present in record tokens.
+func tokensRatio(queryTokens, rowTokens []string) float64 {
+	var found int
+	for _, token := range queryTokens {
+		if includes(rowTokens, token) {
+			found++
+		}
+	}
+
+	return float64(found) / float64(len(queryTokens))
+}
+
+func uniqueTokens(s string) []string {
+	fields := strings.FieldsFunc(normalizeText(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !includes(tokens, field) {
+			tokens = append(tokens, field)
+		}
+	}
+
+	return tokens
+}
+
+func normalizeText(s string) string {
+	return strings.NewReplacer("Ё", "Е").Replace(strings.ToUpper(s))
+}
+
+// normalizeDocument keeps only letters and digits of document number.
+func normalizeDocument(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, normalizeText(s))
+}
+
+// documents returns normalized variants of document numbers of the record.
+func documents(row terreader.Row) []string {
+	numbers := []string{row.Sd + row.Nd, row.Nd, row.Rg}
+	for _, doc := range row.OtherDocuments {
+		numbers = append(numbers, doc.Sd+doc.Nd, doc.Nd)
+	}
+
+	var docs []string
+	for _, doc := range numbers {
+		if doc = normalizeDocument(doc); doc != "" && !includes(docs, doc) {
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs
+}
+
+func birthYear(row terreader.Row) int {
+	if row.Gr != nil {
+		return row.Gr.Year()
+	}
+
+	var year int
+	if _, err := fmt.Sscanf(row.Yr, "%d", &year); err != nil {
+		return 0
+	}
+
+	return year
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+func includes(slice []string, el string) bool {
+	for _, v := range slice {
+		if v == el {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,145 @@
+package screen
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/will-evil/terreader"
+)
+
+const fileEncoding = "866"
+const filePath = "../test/data/testfile.dbf"
+
+func getTestRows() []terreader.Row {
+	gr := time.Date(1988, time.September, 5, 0, 0, 0, 0, time.UTC)
+
+	return []terreader.Row{
+		{Number: "1", Nameu: "ИВАНОВ ИВАН ИВАНОВИЧ", Gr: &gr, Sd: "45 06", Nd: "123456", OtherDocuments: []terreader.Document{{Kd: "03", Nd: "987654"}}},
+		{Number: "2", Nameu: "ПЕТРОВ ПЁТР", Yr: "1988"},
+		{Number: "3", Nameu: "ООО \"РОМАШКА\"", Rg: "1027700132195"},
+		{Number: "4", Nameu: "ИВАНОВА МАРИЯ"},
+	}
+}
+
+func TestIndex_Get(t *testing.T) {
+	idx := NewIndex(getTestRows())
+
+	if idx.Len() != 4 {
+		t.Errorf("index length not correct. Expected 4, got %d", idx.Len())
+	}
+
+	row, ok := idx.Get("3")
+	if !ok {
+		t.Fatal("record with number '3' not found")
+	}
+	if row.Rg != "1027700132195" {
+		t.Errorf("get not correct Row. Got %+v", row)
+	}
+
+	if _, ok := idx.Get("5"); ok {
+		t.Error("found not existing record with number '5'")
+	}
+}
+
+func TestIndex_Screen(t *testing.T) {
+	idx := NewIndex(getTestRows())
+	birthDate := time.Date(1988, time.September, 5, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		query   Query
+		limit   int
+		numbers []string
+		matched [][]string
+		scores  []float64
+	}{
+		{
+			name:    "full name, birth date and document",
+			query:   Query{Name: "иванов иван", BirthDate: &birthDate, Document: "4506 123456"},
+			numbers: []string{"1"},
+			matched: [][]string{{MatchedName, MatchedBirthDate, MatchedDocument}},
+			scores:  []float64{1},
+		},
+		{
+			name:    "name with yo letter and birth year",
+			query:   Query{Name: "Петров Пётр", BirthDate: &birthDate},
+			numbers: []string{"2"},
+			matched: [][]string{{MatchedName, MatchedBirthYear}},
+			scores:  []float64{2.5 / 3},
+		},
+		{
+			name:    "partial name match is ranked",
+			query:   Query{Name: "Иванов Мария"},
+			numbers: []string{"1", "4"},
+			matched: [][]string{{MatchedName}, {MatchedName}},
+			scores:  []float64{0.5, 0.5},
+		},
+		{
+			name:    "document only",
+			query:   Query{Document: "1027700132195"},
+			numbers: []string{"3"},
+			matched: [][]string{{MatchedDocument}},
+			scores:  []float64{1},
+		},
+		{
+			name:    "other document of record",
+			query:   Query{Document: "987654"},
+			numbers: []string{"1"},
+			matched: [][]string{{MatchedDocument}},
+			scores:  []float64{1},
+		},
+		{
+			name:    "limit",
+			query:   Query{Name: "Иванов Мария"},
+			limit:   1,
+			numbers: []string{"1"},
+			matched: [][]string{{MatchedName}},
+			scores:  []float64{0.5},
+		},
+		{
+			name:  "birth date only is not a candidate",
+			query: Query{BirthDate: &birthDate},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			hits := idx.Screen(testCase.query, testCase.limit)
+			if len(hits) != len(testCase.numbers) {
+				t.Fatalf("num of hits not correct. Expected %d, got %d: %+v", len(testCase.numbers), len(hits), hits)
+			}
+
+			for i, hit := range hits {
+				if hit.Row.Number != testCase.numbers[i] {
+					t.Errorf("hit %d not correct. Expected number %s, got %s", i, testCase.numbers[i], hit.Row.Number)
+				}
+				if !reflect.DeepEqual(hit.Matched, testCase.matched[i]) {
+					t.Errorf("matched of hit %d not correct. Expected %v, got %v", i, testCase.matched[i], hit.Matched)
+				}
+				if hit.Score != testCase.scores[i] {
+					t.Errorf("score of hit %d not correct. Expected %v, got %v", i, testCase.scores[i], hit.Score)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tr, err := terreader.NewTerReader(filePath, fileEncoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := Load(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if idx.Len() != 1 {
+		t.Errorf("index length not correct. Expected 1, got %d", idx.Len())
+	}
+	if _, ok := idx.Get("1"); !ok {
+		t.Error("record with number '1' not found")
+	}
+}
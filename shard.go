@@ -0,0 +1,89 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Shard structure for store boundaries of part of records which is read by ReadShard.
+type Shard struct {
+	Index int
+	Total int
+	// From and To are numbers of the first and the last records of the shard. They are zero if shard is empty.
+	From uint64
+	To   uint64
+	// Records is a number of records in the shard.
+	Records int
+}
+
+// String returns description of the shard for logging.
+func (s Shard) String() string {
+	if s.Records == 0 {
+		return fmt.Sprintf("shard %d of %d: no records", s.Index, s.Total)
+	}
+
+	return fmt.Sprintf("shard %d of %d: records from %d to %d (%d records)", s.Index, s.Total, s.From, s.To, s.Records)
+}
+
+// LogValue returns boundaries of the shard for slog.
+func (s Shard) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("index", s.Index),
+		slog.Int("total", s.Total),
+		slog.Uint64("from", s.From),
+		slog.Uint64("to", s.To),
+		slog.Int("records", s.Records),
+	)
+}
+
+// WithShard limits reading by part of records with provided index from 0 to total-1. Records are sorted
+// by number and split into total contiguous parts of nearly equal size, so total readers with different
+// indexes read every record of the file exactly once.
+func WithShard(index, total int) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.sharded = true
+		cfg.shardIndex = index
+		cfg.shardTotal = total
+	}
+}
+
+// ReadShard reads part of records with provided index from 0 to total-1, see WithShard and ReadContext.
+// Boundaries of the part are returned as Shard. If WithRange option is provided, boundaries are
+// the ones of records of the part which are in the range.
+func (tr *TerReader) ReadShard(ctx context.Context, index, total int, opts ...ReadOption) (chan RowReadResult, Shard, error) {
+	rowChan, numbers, err := tr.read(ctx, append(opts[:len(opts):len(opts)], WithShard(index, total)))
+	if err != nil {
+		return nil, Shard{}, err
+	}
+
+	shard := Shard{Index: index, Total: total, Records: len(numbers)}
+	if len(numbers) > 0 {
+		shard.From = numbers[0]
+		shard.To = numbers[len(numbers)-1]
+	}
+
+	return rowChan, shard, nil
+}
+
+// shardNumbers returns numbers of the shard with provided index.
+func shardNumbers(numbers []uint64, index, total int) []uint64 {
+	start := len(numbers) * index / total
+	end := len(numbers) * (index + 1) / total
+
+	return numbers[start:end]
+}
@@ -0,0 +1,130 @@
+package terreader
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTerReader_ReadShard(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	etalonShards := []Shard{
+		{Index: 0, Total: 4, From: 1, To: 1, Records: 1},
+		{Index: 1, Total: 4, From: 2, To: 3, Records: 2},
+		{Index: 2, Total: 4, From: 4, To: 4, Records: 1},
+		{Index: 3, Total: 4, From: 5, To: 6, Records: 2},
+	}
+	for i, etalon := range etalonShards {
+		results, shard, err := tr.ReadShard(context.Background(), i, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shard != etalon {
+			t.Errorf("shard not correct. Expected %+v, got %+v", etalon, shard)
+		}
+
+		var etalonNumbers []uint64
+		for number := etalon.From; number <= etalon.To; number++ {
+			etalonNumbers = append(etalonNumbers, number)
+		}
+		if numbers := readNumbers(t, results); !reflect.DeepEqual(numbers, etalonNumbers) {
+			t.Errorf("numbers not correct. Expected %v, got %v", etalonNumbers, numbers)
+		}
+	}
+}
+
+func TestTerReader_ReadShard_coverage(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	etalon := []uint64{1, 2, 3, 4, 5, 6}
+	for total := 1; total <= 8; total++ {
+		var numbers []uint64
+		for index := 0; index < total; index++ {
+			results, shard, err := tr.ReadShard(context.Background(), index, total)
+			if err != nil {
+				t.Fatal(err)
+			}
+			shardNumbers := readNumbers(t, results)
+			if len(shardNumbers) != shard.Records {
+				t.Errorf("records of %s not correct. Got %d", shard, len(shardNumbers))
+			}
+			numbers = append(numbers, shardNumbers...)
+		}
+
+		if !reflect.DeepEqual(numbers, etalon) {
+			t.Errorf("numbers of %d shards not correct. Expected %v, got %v", total, etalon, numbers)
+		}
+	}
+}
+
+func TestTerReader_ReadShard_WithRange(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, shard, err := tr.ReadShard(context.Background(), 1, 2, WithRange(5, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	etalon := Shard{Index: 1, Total: 2, From: 5, To: 6, Records: 2}
+	if shard != etalon {
+		t.Errorf("shard not correct. Expected %+v, got %+v", etalon, shard)
+	}
+	etalonNumbers := []uint64{5, 6}
+	if numbers := readNumbers(t, results); !reflect.DeepEqual(numbers, etalonNumbers) {
+		t.Errorf("numbers not correct. Expected %v, got %v", etalonNumbers, numbers)
+	}
+}
+
+func TestTerReader_ReadShard_WithWrongShard(t *testing.T) {
+	testCases := []struct {
+		index int
+		total int
+		err   error
+	}{
+		{2, 2, errors.New("shard '2' of '2' is not correct")},
+		{-1, 2, errors.New("shard '-1' of '2' is not correct")},
+		{0, -1, errors.New("shard '0' of '-1' is not correct")},
+		{0, 0, errors.New("shard '0' of '0' is not correct")},
+		{1, 0, errors.New("shard '1' of '0' is not correct")},
+	}
+
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, testCase := range testCases {
+		_, _, err := tr.ReadShard(context.Background(), testCase.index, testCase.total)
+		if err == nil {
+			t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+		} else if err.Error() != testCase.err.Error() {
+			t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), err.Error())
+		}
+	}
+}
+
+func TestShard_String(t *testing.T) {
+	testCases := []struct {
+		shard Shard
+		res   string
+	}{
+		{Shard{Index: 1, Total: 4, From: 2, To: 3, Records: 2}, "shard 1 of 4: records from 2 to 3 (2 records)"},
+		{Shard{Index: 0, Total: 8}, "shard 0 of 8: no records"},
+	}
+
+	for _, testCase := range testCases {
+		if res := testCase.shard.String(); res != testCase.res {
+			t.Errorf("get not correct string. Expected \"%s\", got \"%s\"", testCase.res, res)
+		}
+	}
+}
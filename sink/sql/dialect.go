@@ -0,0 +1,91 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect is a SQL dialect of the target database.
+type Dialect int
+
+const (
+	// PostgreSQL dialect.
+	PostgreSQL Dialect = iota
+	// SQLite dialect. Upsert requires SQLite 3.24 or newer.
+	SQLite
+)
+
+// String returns name of the dialect.
+func (d Dialect) String() string {
+	switch d {
+	case PostgreSQL:
+		return "postgresql"
+	case SQLite:
+		return "sqlite"
+	}
+
+	return "unknown"
+}
+
+func (d Dialect) validate() error {
+	switch d {
+	case PostgreSQL, SQLite:
+		return nil
+	}
+
+	return fmt.Errorf("not support dialect '%d'", int(d))
+}
+
+// placeholder returns bind parameter for argument with provided 1-based position.
+func (d Dialect) placeholder(n int) string {
+	if d == PostgreSQL {
+		return "$" + strconv.Itoa(n)
+	}
+
+	return "?"
+}
+
+// maxParams returns maximum number of bind parameters in one statement.
+func (d Dialect) maxParams() int {
+	if d == PostgreSQL {
+		return 65535
+	}
+
+	// Default SQLITE_MAX_VARIABLE_NUMBER for SQLite before 3.32.
+	return 999
+}
+
+func (d Dialect) columnType(trType string) string {
+	switch trType {
+	case "date":
+		return "DATE"
+	case "id":
+		return "BIGINT"
+	case "timestamp":
+		if d == PostgreSQL {
+			return "TIMESTAMP WITH TIME ZONE"
+		}
+		return "TIMESTAMP"
+	}
+
+	return "TEXT"
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,319 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sql provides functional for loading records read by terreader into a database/sql target.
+//
+// Table schema is derived from tr_col and tr_type tags of terreader.Row. Records are upserted by NUMBER column.
+// If all records of the file are loaded by LoadSnapshot, records which are absent in the file are soft-deleted
+// by setting deleted_at column.
+// OtherDocuments are stored in other_documents column as JSON array of objects with kd, sd, nd and vd keys.
+package sql
+
+import (
+	"context"
+	stdsql "database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/will-evil/terreader"
+)
+
+const (
+	defaultBatchSize = 100
+	keyColumn        = "number"
+	documentsColumn  = "other_documents"
+	loadIDColumn     = "load_id"
+	deletedAtColumn  = "deleted_at"
+)
+
+// column stores info about table column which is mapped to field of terreader.Row.
+type column struct {
+	name       string
+	trType     string
+	fieldIndex int
+}
+
+// Stats structure for store result of loading.
+type Stats struct {
+	Upserted int
+	Deleted  int64
+}
+
+// Loader structure that provides functionality for loading records into database table.
+type Loader struct {
+	db        *stdsql.DB
+	dialect   Dialect
+	table     string
+	columns   []column
+	batchSize int
+	now       func() time.Time
+}
+
+// NewLoader is a constructor for Loader structure.
+func NewLoader(db *stdsql.DB, dialect Dialect, table string) (*Loader, error) {
+	if db == nil {
+		return nil, errors.New("db can not be nil")
+	}
+	if err := dialect.validate(); err != nil {
+		return nil, err
+	}
+	if table == "" {
+		return nil, errors.New("table name can not be empty")
+	}
+
+	return &Loader{
+		db:        db,
+		dialect:   dialect,
+		table:     table,
+		columns:   rowColumns(),
+		batchSize: defaultBatchSize,
+		now:       time.Now,
+	}, nil
+}
+
+// WithBatchSize sets number of records which are inserted by one statement.
+// Batch size is decreased if it exceeds limit of bind parameters of the dialect.
+func (l *Loader) WithBatchSize(size int) *Loader {
+	if size > 0 {
+		l.batchSize = size
+	}
+
+	return l
+}
+
+// DDL returns statement for creating target table.
+func (l *Loader) DDL() string {
+	defs := make([]string, 0, len(l.columns)+2)
+	for _, col := range l.columns {
+		def := quoteIdent(col.name) + " " + l.dialect.columnType(col.trType)
+		switch {
+		case col.name == keyColumn:
+			def += " NOT NULL PRIMARY KEY"
+		case col.trType != "date":
+			def += " NOT NULL"
+		}
+		defs = append(defs, def)
+	}
+	defs = append(defs,
+		quoteIdent(loadIDColumn)+" "+l.dialect.columnType("id")+" NOT NULL",
+		quoteIdent(deletedAtColumn)+" "+l.dialect.columnType("timestamp"),
+	)
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n\t%s\n)", quoteIdent(l.table), strings.Join(defs, ",\n\t"))
+}
+
+// CreateTable creates target table if it not exists.
+func (l *Loader) CreateTable(ctx context.Context) error {
+	_, err := l.db.ExecContext(ctx, l.DDL())
+
+	return err
+}
+
+// Load upserts records from provided channel into target table in one transaction.
+// Other records of the table are not changed, so results can be of a read of part of records,
+// for example by ReadRange or ReadShard. Nothing is changed if channel returns a result with error.
+func (l *Loader) Load(ctx context.Context, results <-chan terreader.RowReadResult) (Stats, error) {
+	return l.load(ctx, results, false)
+}
+
+// LoadSnapshot upserts records like Load and marks records which was loaded before but absent in the channel
+// as deleted. Results must be of a read of all records of the file, without range and shard, otherwise
+// records of other parts are marked as deleted too.
+func (l *Loader) LoadSnapshot(ctx context.Context, results <-chan terreader.RowReadResult) (Stats, error) {
+	return l.load(ctx, results, true)
+}
+
+// load upserts records and marks absent records as deleted if snapshot is true.
+func (l *Loader) load(ctx context.Context, results <-chan terreader.RowReadResult, snapshot bool) (Stats, error) {
+	var stats Stats
+
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return stats, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	now := l.now()
+	loadID := now.UnixNano()
+	size := l.effectiveBatchSize()
+	batch := make([]*terreader.Row, 0, size)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		query, args := l.upsertStatement(batch, loadID)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+		stats.Upserted += len(batch)
+		batch = batch[:0]
+
+		return nil
+	}
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		case res, ok := <-results:
+			if !ok {
+				break loop
+			}
+			if res.Error != nil {
+				return stats, fmt.Errorf("record '%d': %w", res.Number, res.Error)
+			}
+			if res.Row == nil {
+				return stats, fmt.Errorf("record '%d' has no row", res.Number)
+			}
+
+			batch = append(batch, res.Row)
+			if len(batch) == size {
+				if err := flush(); err != nil {
+					return stats, err
+				}
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return stats, err
+	}
+	if !snapshot {
+		return stats, tx.Commit()
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s = %s WHERE %s <> %s AND %s IS NULL",
+		quoteIdent(l.table),
+		quoteIdent(deletedAtColumn), l.dialect.placeholder(1),
+		quoteIdent(loadIDColumn), l.dialect.placeholder(2),
+		quoteIdent(deletedAtColumn),
+	)
+	res, err := tx.ExecContext(ctx, query, now, loadID)
+	if err != nil {
+		return stats, err
+	}
+	if stats.Deleted, err = res.RowsAffected(); err != nil {
+		return stats, err
+	}
+
+	return stats, tx.Commit()
+}
+
+func (l *Loader) effectiveBatchSize() int {
+	maxRows := l.dialect.maxParams() / (len(l.columns) + 1)
+	if l.batchSize > maxRows {
+		return maxRows
+	}
+
+	return l.batchSize
+}
+
+func (l *Loader) upsertStatement(rows []*terreader.Row, loadID int64) (string, []interface{}) {
+	names := make([]string, 0, len(l.columns)+1)
+	updates := make([]string, 0, len(l.columns)+1)
+	for _, col := range l.columns {
+		names = append(names, quoteIdent(col.name))
+		if col.name != keyColumn {
+			updates = append(updates, fmt.Sprintf("%[1]s = excluded.%[1]s", quoteIdent(col.name)))
+		}
+	}
+	names = append(names, quoteIdent(loadIDColumn))
+	updates = append(updates,
+		fmt.Sprintf("%[1]s = excluded.%[1]s", quoteIdent(loadIDColumn)),
+		quoteIdent(deletedAtColumn)+" = NULL",
+	)
+
+	args := make([]interface{}, 0, len(rows)*len(names))
+	tuples := make([]string, 0, len(rows))
+	for _, row := range rows {
+		val := reflect.ValueOf(row).Elem()
+		placeholders := make([]string, 0, len(names))
+		for _, col := range l.columns {
+			args = append(args, columnValue(val.Field(col.fieldIndex)))
+			placeholders = append(placeholders, l.dialect.placeholder(len(args)))
+		}
+		args = append(args, loadID)
+		placeholders = append(placeholders, l.dialect.placeholder(len(args)))
+		tuples = append(tuples, "("+strings.Join(placeholders, ", ")+")")
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES %s ON CONFLICT (%s) DO UPDATE SET %s",
+		quoteIdent(l.table),
+		strings.Join(names, ", "),
+		strings.Join(tuples, ", "),
+		quoteIdent(keyColumn),
+		strings.Join(updates, ", "),
+	)
+
+	return query, args
+}
+
+// document structure for store document of record in other_documents column.
+type document struct {
+	Kd string `json:"kd"`
+	Sd string `json:"sd"`
+	Nd string `json:"nd"`
+	Vd string `json:"vd"`
+}
+
+func columnValue(field reflect.Value) interface{} {
+	switch v := field.Interface().(type) {
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case []terreader.Document:
+		documents := make([]document, 0, len(v))
+		for _, doc := range v {
+			documents = append(documents, document{Kd: doc.Kd, Sd: doc.Sd, Nd: doc.Nd, Vd: doc.Vd})
+		}
+		// Marshaling of strings can not fail.
+		b, _ := json.Marshal(documents)
+		return string(b)
+	}
+
+	return field.Interface()
+}
+
+func rowColumns() []column {
+	typ := reflect.TypeOf(terreader.Row{})
+	columns := make([]column, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := field.Tag.Get("tr_col")
+		if field.Tag.Get("tr_type") == "documents" {
+			name = documentsColumn
+		}
+		if name == "" {
+			continue
+		}
+		columns = append(columns, column{
+			name:       strings.ToLower(name),
+			trType:     field.Tag.Get("tr_type"),
+			fieldIndex: i,
+		})
+	}
+
+	return columns
+}
@@ -0,0 +1,347 @@
+package sql
+
+import (
+	"context"
+	stdsql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/will-evil/terreader"
+)
+
+// recorder stores statements which was executed by fake driver.
+type recorder struct {
+	mu         sync.Mutex
+	statements []string
+	args       [][]driver.NamedValue
+	deleted    int64
+}
+
+func (r *recorder) add(query string, args []driver.NamedValue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statements = append(r.statements, query)
+	r.args = append(r.args, args)
+}
+
+var (
+	recordersMu sync.Mutex
+	recorders   = map[string]*recorder{}
+)
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+
+	return &fakeConn{rec: recorders[name]}, nil
+}
+
+type fakeConn struct {
+	rec *recorder
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.rec.add("BEGIN", nil)
+
+	return fakeTx{rec: c.rec}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.rec.add(query, args)
+	if strings.HasPrefix(query, "UPDATE") {
+		return driver.RowsAffected(c.rec.deleted), nil
+	}
+
+	return driver.RowsAffected(0), nil
+}
+
+type fakeTx struct {
+	rec *recorder
+}
+
+func (tx fakeTx) Commit() error {
+	tx.rec.add("COMMIT", nil)
+
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.rec.add("ROLLBACK", nil)
+
+	return nil
+}
+
+func init() {
+	stdsql.Register("terreader_fake", fakeDriver{})
+}
+
+func openFakeDB(t *testing.T) (*stdsql.DB, *recorder) {
+	rec := &recorder{}
+
+	recordersMu.Lock()
+	recorders[t.Name()] = rec
+	recordersMu.Unlock()
+
+	db, err := stdsql.Open("terreader_fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db, rec
+}
+
+func resultsChan(results ...terreader.RowReadResult) chan terreader.RowReadResult {
+	ch := make(chan terreader.RowReadResult, len(results))
+	for _, res := range results {
+		ch <- res
+	}
+	close(ch)
+
+	return ch
+}
+
+func TestNewLoader(t *testing.T) {
+	db, _ := openFakeDB(t)
+
+	testCases := []struct {
+		db      *stdsql.DB
+		dialect Dialect
+		table   string
+		err     error
+	}{
+		{nil, PostgreSQL, "terrorists", errors.New("db can not be nil")},
+		{db, Dialect(42), "terrorists", errors.New("not support dialect '42'")},
+		{db, SQLite, "", errors.New("table name can not be empty")},
+		{db, SQLite, "terrorists", nil},
+	}
+
+	for _, testCase := range testCases {
+		loader, err := NewLoader(testCase.db, testCase.dialect, testCase.table)
+		if testCase.err == nil {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loader == nil {
+				t.Error("get not correct Loader. Expected structure, got nil")
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+		} else if err.Error() != testCase.err.Error() {
+			t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), err.Error())
+		}
+	}
+}
+
+func TestLoader_DDL(t *testing.T) {
+	db, _ := openFakeDB(t)
+
+	testCases := []struct {
+		dialect  Dialect
+		contains []string
+	}{
+		{PostgreSQL, []string{
+			`CREATE TABLE IF NOT EXISTS "terrorists" (`,
+			`"number" TEXT NOT NULL PRIMARY KEY,`,
+			`"adress" TEXT NOT NULL,`,
+			`"cb_date" DATE,`,
+			`"load_id" BIGINT NOT NULL,`,
+			`"deleted_at" TIMESTAMP WITH TIME ZONE`,
+		}},
+		{SQLite, []string{
+			`"terrtype" TEXT NOT NULL,`,
+			`"other_documents" TEXT NOT NULL,`,
+			`"gr" DATE,`,
+			`"deleted_at" TIMESTAMP`,
+		}},
+	}
+
+	for _, testCase := range testCases {
+		loader, err := NewLoader(db, testCase.dialect, "terrorists")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ddl := loader.DDL()
+		for _, part := range testCase.contains {
+			if !strings.Contains(ddl, part) {
+				t.Errorf("%s DDL does not contain \"%s\". Got:\n%s", testCase.dialect, part, ddl)
+			}
+		}
+	}
+}
+
+func TestLoader_Load(t *testing.T) {
+	now := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	gr := time.Date(1988, time.September, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("when load successfully", func(t *testing.T) {
+		db, rec := openFakeDB(t)
+		rec.deleted = 4
+
+		loader, err := NewLoader(db, PostgreSQL, "terrorists")
+		if err != nil {
+			t.Fatal(err)
+		}
+		loader.WithBatchSize(2).now = func() time.Time { return now }
+
+		results := resultsChan(
+			terreader.RowReadResult{Row: &terreader.Row{Number: "1", Gr: &gr}, Number: 1},
+			terreader.RowReadResult{Row: &terreader.Row{Number: "2", OtherDocuments: []terreader.Document{
+				{Kd: "01", Sd: "4510", Nd: "123456"},
+				{Kd: "04", Nd: "AB123"},
+			}}, Number: 2},
+			terreader.RowReadResult{Row: &terreader.Row{Number: "3"}, Number: 3},
+		)
+
+		stats, err := loader.LoadSnapshot(context.Background(), results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Upserted != 3 || stats.Deleted != 4 {
+			t.Errorf("stats not correct. Expected {Upserted:3 Deleted:4}, got %+v", stats)
+		}
+
+		if len(rec.statements) != 5 {
+			t.Fatalf("num of statements not correct. Expected 5, got %d: %v", len(rec.statements), rec.statements)
+		}
+		if rec.statements[0] != "BEGIN" || rec.statements[4] != "COMMIT" {
+			t.Errorf("statements not executed in transaction: %v", rec.statements)
+		}
+
+		columnsNum := len(rowColumns()) + 1
+		for i, rowsNum := range []int{2, 1} {
+			query, args := rec.statements[i+1], rec.args[i+1]
+			if !strings.HasPrefix(query, `INSERT INTO "terrorists" ("number", "terror",`) {
+				t.Errorf("insert statement not correct. Got %s", query)
+			}
+			if !strings.HasSuffix(query, `"load_id" = excluded."load_id", "deleted_at" = NULL`) {
+				t.Errorf("upsert clause not correct. Got %s", query)
+			}
+			if len(args) != rowsNum*columnsNum {
+				t.Errorf("num of args not correct. Expected %d, got %d", rowsNum*columnsNum, len(args))
+			}
+			if !strings.Contains(query, "$"+strconv.Itoa(rowsNum*columnsNum)+")") {
+				t.Errorf("placeholders not correct. Got %s", query)
+			}
+		}
+
+		if v := rec.args[1][0].Value; v != "1" {
+			t.Errorf("value of number column not correct. Expected \"1\", got %v", v)
+		}
+		if v := rec.args[1][14].Value; v != gr {
+			t.Errorf("value of gr column not correct. Expected %v, got %v", gr, v)
+		}
+		if v := rec.args[1][17].Value; v != nil {
+			t.Errorf("value of ce_date column not correct. Expected nil, got %v", v)
+		}
+
+		documentsIndex := len(rowColumns()) - 1
+		if v := rec.args[1][documentsIndex].Value; v != "[]" {
+			t.Errorf("value of other_documents column not correct. Expected \"[]\", got %v", v)
+		}
+		etalonDocuments := `[{"kd":"01","sd":"4510","nd":"123456","vd":""},{"kd":"04","sd":"","nd":"AB123","vd":""}]`
+		if v := rec.args[1][columnsNum+documentsIndex].Value; v != etalonDocuments {
+			t.Errorf("value of other_documents column not correct. Expected %s, got %v", etalonDocuments, v)
+		}
+
+		etalonUpdate := `UPDATE "terrorists" SET "deleted_at" = $1 WHERE "load_id" <> $2 AND "deleted_at" IS NULL`
+		if rec.statements[3] != etalonUpdate {
+			t.Errorf("soft delete statement not correct. Expected %s, got %s", etalonUpdate, rec.statements[3])
+		}
+		if v := rec.args[3][1].Value; v != now.UnixNano() {
+			t.Errorf("load id not correct. Expected %d, got %v", now.UnixNano(), v)
+		}
+	})
+
+	t.Run("when part of records is loaded", func(t *testing.T) {
+		db, rec := openFakeDB(t)
+		rec.deleted = 4
+
+		loader, err := NewLoader(db, SQLite, "terrorists")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results := resultsChan(terreader.RowReadResult{Row: &terreader.Row{Number: "2"}, Number: 2})
+		stats, err := loader.Load(context.Background(), results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Upserted != 1 || stats.Deleted != 0 {
+			t.Errorf("stats not correct. Expected {Upserted:1 Deleted:0}, got %+v", stats)
+		}
+
+		for _, query := range rec.statements {
+			if strings.HasPrefix(query, "UPDATE") {
+				t.Errorf("soft delete statement executed for part of records: %s", query)
+			}
+		}
+		if last := rec.statements[len(rec.statements)-1]; last != "COMMIT" {
+			t.Errorf("transaction not committed. Last statement %s", last)
+		}
+	})
+
+	t.Run("when result has error", func(t *testing.T) {
+		db, rec := openFakeDB(t)
+
+		loader, err := NewLoader(db, SQLite, "terrorists")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results := resultsChan(
+			terreader.RowReadResult{Row: &terreader.Row{Number: "1"}, Number: 1},
+			terreader.RowReadResult{Number: 2, Error: errors.New("can not find a suitable value for 'TU'")},
+		)
+
+		_, err = loader.Load(context.Background(), results)
+		etalonError := errors.New("record '2': can not find a suitable value for 'TU'")
+		if err == nil || err.Error() != etalonError.Error() {
+			t.Fatalf("error not correct. Expected \"%s\", got %v", etalonError, err)
+		}
+
+		for _, query := range rec.statements {
+			if query == "COMMIT" || strings.HasPrefix(query, "INSERT") {
+				t.Errorf("statement executed after error: %s", query)
+			}
+		}
+		if last := rec.statements[len(rec.statements)-1]; last != "ROLLBACK" {
+			t.Errorf("transaction not rolled back. Last statement %s", last)
+		}
+	})
+}
+
+func TestLoader_effectiveBatchSize(t *testing.T) {
+	db, _ := openFakeDB(t)
+
+	loader, err := NewLoader(db, SQLite, "terrorists")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader.WithBatchSize(1000)
+
+	etalon := 999 / (len(rowColumns()) + 1)
+	if size := loader.effectiveBatchSize(); size != etalon {
+		t.Errorf("batch size not correct. Expected %d, got %d", etalon, size)
+	}
+}
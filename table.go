@@ -0,0 +1,122 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Table is a source of rows in layout of terrorists database file.
+// Each row must have NUMBER and ROW_ID columns, rows with the same NUMBER are joined into one record.
+type Table interface {
+	NumberOfRecords() int
+	FieldValueByName(row int, fieldName string) (string, error)
+}
+
+// DeletedRowsTable is a Table which can mark rows as deleted. Table of dbf file implements it.
+type DeletedRowsTable interface {
+	Table
+	RowIsDeleted(row int) bool
+}
+
+// MapTable is a Table which stores rows as maps from column name to value.
+type MapTable struct {
+	rows []map[string]string
+}
+
+// NewMapTable is a constructor for MapTable structure.
+func NewMapTable(rows []map[string]string) *MapTable {
+	return &MapTable{rows: rows}
+}
+
+// NumberOfRecords returns number of rows in table.
+func (mt *MapTable) NumberOfRecords() int {
+	return len(mt.rows)
+}
+
+// FieldValueByName returns value of column for row with provided index.
+func (mt *MapTable) FieldValueByName(row int, fieldName string) (string, error) {
+	if row < 0 || row >= len(mt.rows) {
+		return "", fmt.Errorf("row '%d' not exists", row)
+	}
+
+	value, ok := mt.rows[row][fieldName]
+	if !ok {
+		return "", fmt.Errorf("field '%s' not exists", fieldName)
+	}
+
+	return value, nil
+}
+
+// CSVTable is a Table which reads rows from CSV data. The first CSV record must contain names of columns.
+type CSVTable struct {
+	columns map[string]int
+	records [][]string
+}
+
+// NewCSVTable is a constructor for CSVTable structure.
+func NewCSVTable(r io.Reader) (*CSVTable, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("csv data has no header")
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("column '%s' is duplicated", name)
+		}
+		columns[name] = i
+	}
+
+	return &CSVTable{columns: columns, records: records[1:]}, nil
+}
+
+// NewCSVTableFromFile is a CSVTable constructor for CSV file.
+func NewCSVTableFromFile(filePath string) (*CSVTable, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewCSVTable(f)
+}
+
+// NumberOfRecords returns number of rows in table without header.
+func (ct *CSVTable) NumberOfRecords() int {
+	return len(ct.records)
+}
+
+// FieldValueByName returns value of column for row with provided index.
+func (ct *CSVTable) FieldValueByName(row int, fieldName string) (string, error) {
+	if row < 0 || row >= len(ct.records) {
+		return "", fmt.Errorf("row '%d' not exists", row)
+	}
+
+	i, ok := ct.columns[fieldName]
+	if !ok {
+		return "", fmt.Errorf("field '%s' not exists", fieldName)
+	}
+
+	return ct.records[row][i], nil
+}
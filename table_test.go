@@ -0,0 +1,143 @@
+package terreader
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testCSV = `NUMBER,ROW_ID,TERROR,TU,NAMEU,DESCRIPT,KODCR,KODCN,AMR,ADRESS,KD,SD,RG,ND,VD,GR,YR,MR,CB_DATE,CE_DATE,DIRECTOR,FOUNDER,TERRTYPE
+2,3,1,3,Neque sodales,,,,,,01,,,,,20200821,,,,,,,
+1,2,1,2,Olubunmi Pam,"Diam quam, nulla",,,,,04,,,,,,,,,,,,
+1,1,,2,Olubunmi Pam,,,,,,04,,,,,,,,,,,,
+`
+
+func TestNewTerReaderFromTable(t *testing.T) {
+	t.Run("when table is nil", func(t *testing.T) {
+		tr, err := NewTerReaderFromTable(nil)
+		etalonError := errors.New("table can not be nil")
+		if err == nil || err.Error() != etalonError.Error() {
+			t.Errorf("error not correct. Expected \"%s\", got %v", etalonError, err)
+		}
+		if tr != nil {
+			t.Errorf("get not correct TerReader. Expected nil, got %+v", tr)
+		}
+	})
+
+	t.Run("when read csv table", func(t *testing.T) {
+		table, err := NewCSVTable(strings.NewReader(testCSV))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tr, err := NewTerReaderFromTable(table)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := tr.Read(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var rows []Row
+		for res := range results {
+			if res.Error != nil {
+				t.Fatal(res.Error)
+			}
+			rows = append(rows, *res.Row)
+		}
+
+		if len(rows) != 2 {
+			t.Fatalf("received not correct num of records. Expected 2, got %d", len(rows))
+		}
+		etalon := Row{Number: "1", Terror: "1", Tu: "2", Nameu: "Olubunmi Pam", Descript: "Diam quam, nulla", Kd: "04", RowID: "1"}
+		if !reflect.DeepEqual(rows[0], etalon) {
+			t.Errorf("get not correct Row. Expected %+v, got %+v", etalon, rows[0])
+		}
+		if rows[1].Number != "2" || rows[1].Gr == nil {
+			t.Errorf("get not correct Row. Got %+v", rows[1])
+		}
+	})
+}
+
+func TestMapTable_FieldValueByName(t *testing.T) {
+	table := NewMapTable([]map[string]string{{"NUMBER": "1"}})
+
+	testCases := []struct {
+		row   int
+		field string
+		value string
+		err   error
+	}{
+		{0, "NUMBER", "1", nil},
+		{0, "ROW_ID", "", errors.New("field 'ROW_ID' not exists")},
+		{1, "NUMBER", "", errors.New("row '1' not exists")},
+	}
+
+	for _, testCase := range testCases {
+		value, err := table.FieldValueByName(testCase.row, testCase.field)
+		if testCase.err == nil && err != nil {
+			t.Fatal(err)
+		}
+		if testCase.err != nil {
+			if err == nil {
+				t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+			} else if err.Error() != testCase.err.Error() {
+				t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), err.Error())
+			}
+		}
+		if value != testCase.value {
+			t.Errorf("value not correct. Expected \"%s\", got \"%s\"", testCase.value, value)
+		}
+	}
+}
+
+func TestNewCSVTable(t *testing.T) {
+	testCases := []struct {
+		data string
+		err  error
+	}{
+		{"", errors.New("csv data has no header")},
+		{"NUMBER,NUMBER\n1,2\n", errors.New("column 'NUMBER' is duplicated")},
+		{"NUMBER,ROW_ID\n1\n", errors.New("record on line 2: wrong number of fields")},
+	}
+
+	for _, testCase := range testCases {
+		table, err := NewCSVTable(strings.NewReader(testCase.data))
+		if err == nil {
+			t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+		} else if err.Error() != testCase.err.Error() {
+			t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), err.Error())
+		}
+		if table != nil {
+			t.Errorf("get not correct CSVTable. Expected nil, got %+v", table)
+		}
+	}
+
+	table, err := NewCSVTable(strings.NewReader(testCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.NumberOfRecords() != 3 {
+		t.Errorf("number of records not correct. Expected 3, got %d", table.NumberOfRecords())
+	}
+	if _, err := table.FieldValueByName(3, "NUMBER"); err == nil || err.Error() != "row '3' not exists" {
+		t.Errorf("error not correct. Expected \"row '3' not exists\", got %v", err)
+	}
+	if _, err := table.FieldValueByName(0, "NOT_EXISTS"); err == nil || err.Error() != "field 'NOT_EXISTS' not exists" {
+		t.Errorf("error not correct. Expected \"field 'NOT_EXISTS' not exists\", got %v", err)
+	}
+}
+
+func TestNewCSVTableFromFile(t *testing.T) {
+	table, err := NewCSVTableFromFile("not/exists/file.csv")
+	etalonError := errors.New("open not/exists/file.csv: no such file or directory")
+	if err == nil || err.Error() != etalonError.Error() {
+		t.Errorf("error not correct. Expected \"%s\", got %v", etalonError, err)
+	}
+	if table != nil {
+		t.Errorf("get not correct CSVTable. Expected nil, got %+v", table)
+	}
+}
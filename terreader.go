@@ -19,171 +19,512 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/will-evil/go-dbf/godbf"
 )
 
 const (
-	dateFormat               = "20060102"
-	needTrailingSpaceCharNum = 253
+	dateFormat = "20060102"
+	// dbfTextFieldLength is a width of text fields in terrorists database file.
+	// It is used if width of field is unknown, for example for custom tables.
+	dbfTextFieldLength = 254
+	// minFreeSlotInterval and maxFreeSlotInterval are bounds of interval of checking of free slot in chan with results.
+	minFreeSlotInterval = 50 * time.Microsecond
+	maxFreeSlotInterval = 5 * time.Millisecond
 )
 
 var newFromByteSlice = godbf.NewFromByteArray
 
-type dbfTable interface {
-	NumberOfRecords() int
-	FieldValueByName(row int, fieldName string) (string, error)
-}
+// ErrReaderClosed is returned by Read of closed reader. It is also sent as the last result
+// of reads which are stopped by Close.
+var ErrReaderClosed = errors.New("reader is closed")
 
 // rowData structure for store info about row from dbf terrorist file.
 // This struct stores row index in file and value of column ROW-ID.
 type rowData struct {
-	index int
-	rowID uint64
+	index   int
+	rowID   uint64
+	deleted bool
 }
 
 type rowDataMap map[uint64][]rowData
 
 // TerReader structure that provides functionality for reading dbf file.
+// Index of rows is built once by the first Read and it is not changed after that, so Read can be called
+// from several goroutines simultaneously. Options must be set before the first Read.
 type TerReader struct {
-	dbfTable             dbfTable
-	rowDataMap           rowDataMap
-	rowNumbers           []uint64
-	ctx                  context.Context
-	allowEmptyEnumValues bool
+	table              Table
+	encoding           string
+	header             *dbfHeader
+	fileSize           int64
+	rowDataMap         rowDataMap
+	rowNumbers         []uint64
+	indexOnce          sync.Once
+	indexErr           error
+	mu                 sync.Mutex
+	closed             bool
+	done               chan struct{}
+	reads              sync.WaitGroup
+	ctx                context.Context
+	config             readConfig
+	includeDeletedRows bool
+	skippedDeletedRows int
 }
 
 // NewTerReader is a constructor for TerReader structure.
+// Encoding can be EncodingAuto, then it is detected by content of the file.
 func NewTerReader(filePath, encoding string) (*TerReader, error) {
-	dbfTable, err := godbf.NewFromFile(filePath, encoding)
+	data, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TerReader{dbfTable: dbfTable, ctx: context.Background()}, nil
+	return NewTerReaderFromByteSlice(data, encoding)
 }
 
 // NewTerReaderFromByteSlice is a TerReader constructor for slice of bytes.
+// Encoding can be EncodingAuto, then it is detected by content of the data and the header must be correct.
+// Otherwise Metadata returns ErrNoMetadata if the header can not be parsed.
+// NUL bytes of records in data are replaced by spaces, so data must not be changed or used by caller after the call.
 func NewTerReaderFromByteSlice(data []byte, encoding string) (*TerReader, error) {
+	fillBlankPadding(data)
+
+	var header *dbfHeader
+	if encoding == EncodingAuto {
+		var err error
+		if header, err = parseDBFHeader(data); err != nil {
+			return nil, err
+		}
+		encoding = detectEncoding(data, header)
+	}
+
 	dbfTable, err := newFromByteSlice(data, encoding)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TerReader{dbfTable: dbfTable, ctx: context.Background()}, nil
+	// Header is needed only for metadata if encoding is provided, so the data which go-dbf accepts is not rejected.
+	if header == nil {
+		header, _ = parseDBFHeader(data)
+	}
+
+	return &TerReader{
+		table:    dbfTable,
+		encoding: encoding,
+		header:   header,
+		fileSize: int64(len(data)),
+		ctx:      context.Background(),
+	}, nil
+}
+
+// NewTerReaderFromTable is a TerReader constructor for custom source of rows.
+func NewTerReaderFromTable(table Table) (*TerReader, error) {
+	if table == nil {
+		return nil, errors.New("table can not be nil")
+	}
+
+	return &TerReader{table: table, ctx: context.Background()}, nil
+}
+
+// DetectedEncoding returns encoding which is used for decoding the file.
+// It is the detected encoding if reader was created with EncodingAuto and empty string for readers of custom tables.
+func (tr *TerReader) DetectedEncoding() string {
+	return tr.encoding
 }
 
 // WithContext set provided value like a value for ctx field in TerReader object.
-// The provided ctx must be non-nil.
+// The provided ctx must be non-nil. Read uses context which is set at the moment of the call.
+// Context is shared by all calls of Read, so reads with different contexts must use ReadContext,
+// tr.WithContext(ctx).Read(0) from several goroutines uses context of any of them.
 func (tr *TerReader) WithContext(ctx context.Context) *TerReader {
+	tr.mu.Lock()
 	tr.ctx = ctx
+	tr.mu.Unlock()
 
 	return tr
 }
 
+// context returns context which is set by WithContext.
+func (tr *TerReader) context() context.Context {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return tr.ctx
+}
+
 // AllowEmptyEnumValues sets allowEmptyEnumValues option to true.
 // Option allowEmptyEnumValues says then not need return error if enum field is empty in all rows of record.
 func (tr *TerReader) AllowEmptyEnumValues() *TerReader {
-	tr.allowEmptyEnumValues = true
+	tr.config.allowEmptyEnumValues = true
+
+	return tr
+}
+
+// RegisterEnum sets allowed values of enum field. Default values of the field are replaced.
+func (tr *TerReader) RegisterEnum(fieldName string, values ...string) *TerReader {
+	tr.config.setEnum(fieldName, values)
+
+	return tr
+}
+
+// ExtendEnum adds values to allowed values of enum field, for example a new code of document type for KD.
+func (tr *TerReader) ExtendEnum(fieldName string, values ...string) *TerReader {
+	tr.config.extendEnum(fieldName, values)
 
 	return tr
 }
 
+// AllowUnknownEnumValues sets allowUnknownEnums option to true.
+// With this option values of enum fields which are not allowed are used like allowed ones,
+// and DiagnosticUnknownEnumValue is reported for them in RowReadResult.Diagnostics.
+func (tr *TerReader) AllowUnknownEnumValues() *TerReader {
+	tr.config.allowUnknownEnums = true
+
+	return tr
+}
+
+// IncludeDeletedRows sets includeDeletedRows option to true.
+// By default rows marked as deleted in dbf file are skipped. With this option they are joined into records
+// like other rows and RowReadResult.Deleted is set for records which include such rows.
+func (tr *TerReader) IncludeDeletedRows() *TerReader {
+	tr.includeDeletedRows = true
+
+	return tr
+}
+
+// Strict sets strict option to true.
+// In strict mode values of static, enum and date fields are compared in all rows of record
+// and conflicts are reported in RowReadResult.Diagnostics.
+func (tr *TerReader) Strict() *TerReader {
+	tr.config.strict = true
+
+	return tr
+}
+
+// WithConflictPolicy sets policy which chooses value if rows of record have different values
+// of static, enum or date field. Default policy is ConflictFirst, see ConflictPolicy for details.
+func (tr *TerReader) WithConflictPolicy(policy ConflictPolicy) *TerReader {
+	tr.config.conflictPolicy = policy
+
+	return tr
+}
+
+// WithLogger sets logger for decisions which are made while records are built, see WithLogger option.
+func (tr *TerReader) WithLogger(logger *slog.Logger) *TerReader {
+	tr.config.logger = logger
+
+	return tr
+}
+
+// OnProgress sets function which is called with progress of indexing and reading, see WithProgress.
+func (tr *TerReader) OnProgress(fn ProgressFunc) *TerReader {
+	tr.config.progress = fn
+
+	return tr
+}
+
+// SkippedDeletedRows returns number of rows which was skipped because they are marked as deleted.
+// Value is known after the first call of Read.
+func (tr *TerReader) SkippedDeletedRows() int {
+	return tr.skippedDeletedRows
+}
+
 // Read return chan for retry records from dbf terrorist file.
+// It uses context set by WithContext, see ReadContext for details.
 func (tr *TerReader) Read(chanBuff uint) (chan RowReadResult, error) {
-	if err := tr.setHelpData(); err != nil {
-		return nil, err
+	return tr.ReadContext(tr.context(), WithBuffer(chanBuff))
+}
+
+// ReadContext return chan for retry records from dbf terrorist file. Provided options are applied
+// over options of the reader only for this call, so simultaneous reads can use different options.
+// If ctx is done or reader is closed, reading is stopped and ctx.Err() or ErrReaderClosed is always sent
+// as the last result. One slot of the chan is reserved for it, so producer never blocks after that
+// and the consumer can stop reading the chan.
+func (tr *TerReader) ReadContext(ctx context.Context, opts ...ReadOption) (chan RowReadResult, error) {
+	rowChan, _, err := tr.read(ctx, opts)
+
+	return rowChan, err
+}
+
+// read starts reading of records like ReadContext and returns numbers of records which are read.
+func (tr *TerReader) read(ctx context.Context, opts []ReadOption) (chan RowReadResult, []uint64, error) {
+	cfg := tr.config
+	for _, opt := range opts {
+		opt(&cfg)
 	}
+	if err := cfg.validateSelection(); err != nil {
+		return nil, nil, err
+	}
+
+	tr.mu.Lock()
+	if tr.closed {
+		tr.mu.Unlock()
+		return nil, nil, ErrReaderClosed
+	}
+	done := tr.doneChan()
+	tr.reads.Add(1)
+	tr.mu.Unlock()
 
-	rowChan := make(chan RowReadResult, chanBuff)
+	if err := tr.setHelpData(&cfg); err != nil {
+		tr.reads.Done()
+		return nil, nil, err
+	}
+
+	numbers := cfg.selectNumbers(tr.rowNumbers)
+	// Records use at most limit slots of the buffer, the last slot is kept free for the reason of stopping.
+	limit := int(cfg.chanBuff)
+	if limit == 0 {
+		limit = 1
+	}
+	rowChan := make(chan RowReadResult, limit+1)
 	go func() {
+		defer tr.reads.Done()
 		defer close(rowChan)
 
+		send := func(res RowReadResult) bool {
+			if !waitFreeSlot(ctx, done, func() int { return len(rowChan) }, limit) {
+				return false
+			}
+			rowChan <- res
+
+			return true
+		}
+
 		resWithError := func(number uint64, err error) RowReadResult {
 			return RowReadResult{Number: number, Error: err}
 		}
 
-	loop:
-		for _, number := range tr.rowNumbers {
-			select {
-			case <-tr.ctx.Done():
-				break loop
-			default:
-				rowDataSlice, ok := tr.rowDataMap[number]
-				if !ok {
-					rowChan <- resWithError(number, fmt.Errorf("key '%d' not exists is map rowDataMap", number))
-					break loop
-				}
-
-				row, err := tr.buildRecord(rowDataSlice)
-				if err != nil {
-					rowChan <- resWithError(number, err)
-					break loop
-				}
-
-				rowChan <- RowReadResult{Row: row, Number: number}
+		var records int
+		var readErr error
+		progress := newProgressTracker(cfg.progress, ProgressReading, len(numbers))
+		for i, number := range numbers {
+			if stopErr(ctx, done) != nil {
+				break
+			}
+
+			rowDataSlice, ok := tr.rowDataMap[number]
+			if !ok {
+				readErr = fmt.Errorf("key '%d' not exists is map rowDataMap", number)
+				send(resWithError(number, readErr))
+				break
+			}
+
+			start := time.Now()
+			row, diagnostics, err := tr.buildRecord(cfg.forRecord(number), rowDataSlice)
+			cfg.recordBuilt(number, time.Since(start), err)
+			if err != nil {
+				readErr = err
+				send(resWithError(number, err))
+				break
+			}
+
+			res := RowReadResult{
+				Row:         row,
+				Number:      number,
+				Deleted:     hasDeletedRows(rowDataSlice),
+				Diagnostics: diagnostics,
+			}
+			if !send(res) {
+				break
 			}
+			records++
+			progress.report(i + 1)
+		}
+
+		if err := stopErr(ctx, done); err != nil {
+			if readErr == nil {
+				readErr = err
+			}
+			// Producer is the only sender and records never take the last slot, so it never blocks.
+			rowChan <- RowReadResult{Error: err}
+		}
+		cfg.readFinished(records, readErr)
+		if readErr == nil && !cfg.limited && !cfg.sharded {
+			var lastUpdate time.Time
+			if tr.header != nil {
+				lastUpdate = tr.header.lastUpdate()
+			}
+			cfg.listLoaded(lastUpdate)
 		}
 	}()
 
-	return rowChan, nil
+	return rowChan, numbers, nil
 }
 
-func (tr *TerReader) setHelpData() error {
-	if len(tr.rowDataMap) >= 1 {
+// waitFreeSlot waits until the chan has less than limit results. Consumer does not notify producer
+// about received results, so length of the chan is checked with growing interval.
+// False is returned if reading is stopped while waiting.
+func waitFreeSlot(ctx context.Context, done <-chan struct{}, length func() int, limit int) bool {
+	interval := minFreeSlotInterval
+	for length() >= limit {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-done:
+			return false
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > maxFreeSlotInterval {
+			interval = maxFreeSlotInterval
+		}
+	}
+
+	return stopErr(ctx, done) == nil
+}
+
+// stopErr returns reason of stopping of reading or nil if reading can be continued.
+func stopErr(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return ErrReaderClosed
+	default:
+		return ctx.Err()
+	}
+}
+
+// doneChan returns chan which is closed by Close. Mutex must be locked by caller.
+func (tr *TerReader) doneChan() chan struct{} {
+	if tr.done == nil {
+		tr.done = make(chan struct{})
+	}
+
+	return tr.done
+}
+
+// Close stops reads which are in progress, waits for them and releases data of the file.
+// If custom table implements io.Closer, it is closed too. Reader can not be used after Close.
+func (tr *TerReader) Close() error {
+	tr.mu.Lock()
+	if tr.closed {
+		tr.mu.Unlock()
 		return nil
 	}
+	tr.closed = true
+	close(tr.doneChan())
+	tr.mu.Unlock()
+
+	tr.reads.Wait()
+
+	var err error
+	if closer, ok := tr.table.(io.Closer); ok {
+		err = closer.Close()
+	}
+	tr.table = nil
+	tr.rowDataMap = nil
+	tr.rowNumbers = nil
+
+	return err
+}
+
+// setHelpData builds index of rows once, the result is shared by all calls of Read.
+// Progress and hooks of provided config are used for indexing, config can be nil.
+func (tr *TerReader) setHelpData(cfg *readConfig) error {
+	tr.indexOnce.Do(func() {
+		if cfg == nil {
+			cfg = &readConfig{}
+		}
+
+		start := time.Now()
+		var rows int
+		rows, tr.indexErr = tr.buildIndex(cfg)
+		cfg.indexBuilt(rows, time.Since(start), tr.indexErr)
+	})
+
+	return tr.indexErr
+}
+
+// buildIndex groups rows by number and orders rows of every record by ROW_ID.
+// It returns number of scanned rows.
+func (tr *TerReader) buildIndex(cfg *readConfig) (int, error) {
+	if len(tr.rowDataMap) >= 1 {
+		return 0, nil
+	}
 
 	tr.rowDataMap = make(rowDataMap)
+	tr.skippedDeletedRows = 0
+
+	deletedRowsTable, _ := tr.table.(DeletedRowsTable)
+	progress := newProgressTracker(cfg.progress, ProgressIndexing, tr.table.NumberOfRecords())
 
-	for i := 0; i < tr.dbfTable.NumberOfRecords(); i++ {
-		numberStr, err := tr.dbfTable.FieldValueByName(i, "NUMBER")
+	for i := 0; i < tr.table.NumberOfRecords(); i++ {
+		progress.report(i)
+
+		deleted := deletedRowsTable != nil && deletedRowsTable.RowIsDeleted(i)
+		if deleted && !tr.includeDeletedRows {
+			tr.skippedDeletedRows++
+			// Value of deleted row is only logged, so it is not checked.
+			number, _ := tr.table.FieldValueByName(i, "NUMBER")
+			cfg.log(slog.LevelDebug, "deleted row is skipped", "number", number, "index", i)
+			continue
+		}
+
+		numberStr, err := tr.table.FieldValueByName(i, "NUMBER")
 		if err != nil {
-			return err
+			return i, err
 		}
-		rowIDStr, err := tr.dbfTable.FieldValueByName(i, "ROW_ID")
+		rowIDStr, err := tr.table.FieldValueByName(i, "ROW_ID")
 		if err != nil {
-			return err
+			return i, err
 		}
 
 		number, err := strconv.ParseUint(numberStr, 10, 64)
 		if err != nil {
-			return err
+			return i, err
 		}
 		rowID, err := strconv.ParseUint(rowIDStr, 10, 64)
 		if err != nil {
-			return err
+			return i, err
 		}
 
-		data := rowData{index: i, rowID: rowID}
+		data := rowData{index: i, rowID: rowID, deleted: deleted}
 		if _, ok := tr.rowDataMap[number]; !ok {
 			tr.rowNumbers = append(tr.rowNumbers, number)
 		}
 		tr.rowDataMap[number] = append(tr.rowDataMap[number], data)
 	}
+	progress.report(tr.table.NumberOfRecords())
 
 	sort.Slice(tr.rowNumbers, func(i, j int) bool {
 		return tr.rowNumbers[i] < tr.rowNumbers[j]
 	})
 
-	return nil
+	for _, rowDataSlice := range tr.rowDataMap {
+		sort.SliceStable(rowDataSlice, func(i, j int) bool {
+			return rowDataSlice[i].rowID < rowDataSlice[j].rowID
+		})
+	}
+
+	return tr.table.NumberOfRecords(), nil
 }
 
-func (tr *TerReader) buildRecord(rowDataSlice []rowData) (*Row, error) {
-	if len(rowDataSlice) == 0 {
-		return nil, errors.New("rowDataSlice can not be empty")
+func hasDeletedRows(rowDataSlice []rowData) bool {
+	for _, data := range rowDataSlice {
+		if data.deleted {
+			return true
+		}
 	}
 
-	sort.SliceStable(rowDataSlice, func(i, j int) bool {
-		return rowDataSlice[i].rowID < rowDataSlice[j].rowID
-	})
+	return false
+}
+
+// buildRecord builds record from rows which are ordered by ROW_ID.
+func (tr *TerReader) buildRecord(cfg *readConfig, rowDataSlice []rowData) (*Row, []Diagnostic, error) {
+	if len(rowDataSlice) == 0 {
+		return nil, nil, errors.New("rowDataSlice can not be empty")
+	}
 
 	row := &Row{}
+	var diagnostics []Diagnostic
 
 	val := reflect.ValueOf(row).Elem()
 
@@ -194,58 +535,96 @@ func (tr *TerReader) buildRecord(rowDataSlice []rowData) (*Row, error) {
 		fieldType := typeField.Tag.Get("tr_type")
 		switch fieldType {
 		case "static":
-			val, err := tr.dbfTable.FieldValueByName(rowDataSlice[0].index, fieldName)
+			val, d, err := tr.getStaticValue(cfg, fieldName, rowDataSlice)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			valueField.SetString(val)
+			diagnostics = append(diagnostics, d...)
 		case "enum":
-			val, err := tr.getEnumValue(fieldName, rowDataSlice)
+			val, d, err := tr.getEnumValue(cfg, fieldName, rowDataSlice)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			valueField.SetString(val)
+			diagnostics = append(diagnostics, d...)
 		case "date":
-			val, err := tr.getDateValue(fieldName, rowDataSlice[0].index)
+			val, d, err := tr.getDateValue(cfg, fieldName, rowDataSlice)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			valueField.Set(reflect.ValueOf(val))
+			diagnostics = append(diagnostics, d...)
 		case "text":
-			val, err := tr.getTextValue(fieldName, rowDataSlice)
+			val, d, err := tr.getTextValue(cfg, fieldName, rowDataSlice)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			valueField.SetString(val)
+			diagnostics = append(diagnostics, d...)
+		case "documents":
+			// Documents field is the last one, so document fields of the row are already set.
+			main := Document{Kd: row.Kd, Sd: row.Sd, Nd: row.Nd, Vd: row.Vd}
+			val, err := tr.getDocuments(rowDataSlice, main)
+			if err != nil {
+				return nil, nil, err
+			}
+			valueField.Set(reflect.ValueOf(val))
 		}
 	}
 
-	return row, nil
+	return row, diagnostics, nil
 }
 
-func (tr *TerReader) getDateValue(fieldName string, rowIndex int) (*time.Time, error) {
-	val, err := tr.dbfTable.FieldValueByName(rowIndex, fieldName)
+// getStaticValue returns value of static field. ROW_ID is taken from the first row, it is different in every row.
+// Other fields are chosen by conflict policy.
+func (tr *TerReader) getStaticValue(cfg *readConfig, fieldName string, rowDataSlice []rowData) (string, []Diagnostic, error) {
+	if fieldName == "ROW_ID" {
+		val, err := tr.table.FieldValueByName(rowDataSlice[0].index, fieldName)
+
+		return val, nil, newRecordError(ErrorKindField, fieldName, err)
+	}
+
+	values, err := tr.fieldValues(fieldName, rowDataSlice, nil)
 	if err != nil {
-		return nil, err
+		return "", nil, err
+	}
+
+	// With ConflictFirst value of the first row is used even if it is empty.
+	if cfg.conflictPolicy == ConflictFirst && (len(values) == 0 || values[0].index != rowDataSlice[0].index) {
+		first := fieldValue{rowID: rowDataSlice[0].rowID, index: rowDataSlice[0].index}
+		values = append([]fieldValue{first}, values...)
+	}
+
+	return cfg.resolveConflict(fieldName, values)
+}
+
+func (tr *TerReader) getDateValue(cfg *readConfig, fieldName string, rowDataSlice []rowData) (*time.Time, []Diagnostic, error) {
+	val, diagnostics, err := tr.getStaticValue(cfg, fieldName, rowDataSlice)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if val == "" {
-		return nil, nil
+		return nil, diagnostics, nil
 	}
 
-	t, err := time.Parse(dateFormat, val)
+	t, err := cfg.parseDate(val)
+	if err != nil {
+		return nil, nil, newRecordError(ErrorKindDate, fieldName, err)
+	}
 
-	return &t, err
+	return &t, diagnostics, nil
 }
 
-func (tr *TerReader) getEnumValue(fieldName string, rowDataSlice []rowData) (string, error) {
-	enumValues, err := getEnum(fieldName)
+func (tr *TerReader) getEnumValue(cfg *readConfig, fieldName string, rowDataSlice []rowData) (string, []Diagnostic, error) {
+	enumValues, err := cfg.enumValues(fieldName)
 	if err != nil {
-		return "", err
+		return "", nil, newRecordError(ErrorKindEnum, fieldName, err)
 	}
 
-	isInclude := func(el string, slice []string) bool {
-		for _, v := range slice {
+	isInclude := func(el string) bool {
+		for _, v := range enumValues {
 			if el == v {
 				return true
 			}
@@ -254,49 +633,200 @@ func (tr *TerReader) getEnumValue(fieldName string, rowDataSlice []rowData) (str
 		return false
 	}
 
-	for _, data := range rowDataSlice {
-		val, err := tr.dbfTable.FieldValueByName(data.index, fieldName)
-		if err != nil {
-			return "", err
+	var filter func(string) bool
+	if !cfg.allowUnknownEnums {
+		filter = isInclude
+	}
+
+	values, err := tr.fieldValues(fieldName, rowDataSlice, filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(values) == 0 {
+		if cfg.allowEmptyEnumValues {
+			cfg.log(slog.LevelDebug, "enum field has no allowed value and it is left empty", "column", fieldName)
+			return "", nil, nil
 		}
 
-		if isInclude(val, enumValues) {
-			return val, nil
+		err := fmt.Errorf("can not find a suitable value for '%s'", fieldName)
+
+		return "", nil, newRecordError(ErrorKindEnum, fieldName, err)
+	}
+
+	var unknownDiagnostics []Diagnostic
+	for _, v := range values {
+		if !isInclude(v.value) {
+			cfg.log(slog.LevelWarn, "value of enum field is not allowed and it is used",
+				"column", fieldName, "index", v.index, "value", v.value)
+			unknownDiagnostics = append(unknownDiagnostics, Diagnostic{
+				Kind:    DiagnosticUnknownEnumValue,
+				Field:   fieldName,
+				RowID:   v.rowID,
+				Message: fmt.Sprintf("value '%s' is not allowed", v.value),
+			})
 		}
 	}
 
-	if tr.allowEmptyEnumValues {
-		return "", nil
+	val, diagnostics, err := cfg.resolveConflict(fieldName, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return val, append(unknownDiagnostics, diagnostics...), nil
+}
+
+// fieldValues returns not empty values of field in rows of record. If filter is set, only values accepted by it
+// are returned.
+func (tr *TerReader) fieldValues(fieldName string, rowDataSlice []rowData, filter func(string) bool) ([]fieldValue, error) {
+	values := make([]fieldValue, 0, len(rowDataSlice))
+	for _, data := range rowDataSlice {
+		val, err := tr.table.FieldValueByName(data.index, fieldName)
+		if err != nil {
+			return nil, newRecordError(ErrorKindField, fieldName, err)
+		}
+
+		if val == "" || (filter != nil && !filter(val)) {
+			continue
+		}
+		values = append(values, fieldValue{value: val, rowID: data.rowID, index: data.index})
 	}
 
-	return "", fmt.Errorf("can not find a suitable value for '%s'", fieldName)
+	return values, nil
 }
 
-func (tr *TerReader) getTextValue(fieldName string, rowDataSlice []rowData) (string, error) {
-	var text string
-	var lastIncludedStrLen int
+func (tr *TerReader) getTextValue(cfg *readConfig, fieldName string, rowDataSlice []rowData) (string, []Diagnostic, error) {
+	joiner := textJoiner{field: fieldName, width: tr.fieldWidth(fieldName), cfg: cfg}
 
 	for _, data := range rowDataSlice {
-		val, err := tr.dbfTable.FieldValueByName(data.index, fieldName)
+		val, err := tr.table.FieldValueByName(data.index, fieldName)
 		if err != nil {
-			return "", err
+			return "", nil, newRecordError(ErrorKindField, fieldName, err)
 		}
 
-		if strings.Contains(text, val) || val == "" {
+		joiner.add(val, data)
+	}
+
+	return joiner.text, joiner.diagnostics, nil
+}
+
+// getDocuments returns distinct documents from rows of record in order of ROW_ID except the main one.
+func (tr *TerReader) getDocuments(rowDataSlice []rowData, main Document) ([]Document, error) {
+	var documents []Document
+	for _, data := range rowDataSlice {
+		values := make([]string, len(documentColumns))
+		for i, column := range documentColumns {
+			val, err := tr.table.FieldValueByName(data.index, column)
+			if err != nil {
+				return nil, newRecordError(ErrorKindField, column, err)
+			}
+			values[i] = val
+		}
+
+		doc := Document{Kd: values[0], Sd: values[1], Nd: values[2], Vd: values[3]}
+		if doc.isEmpty() || doc == main || includesDocument(documents, doc) {
 			continue
 		}
+		documents = append(documents, doc)
+	}
+
+	return documents, nil
+}
+
+func includesDocument(documents []Document, doc Document) bool {
+	for _, d := range documents {
+		if d == doc {
+			return true
+		}
+	}
 
-		leadingChar := ""
-		if lastIncludedStrLen == needTrailingSpaceCharNum {
-			leadingChar = " "
+	return false
+}
+
+// fieldWidth returns width of the field from dbf header or dbfTextFieldLength if header is unknown.
+func (tr *TerReader) fieldWidth(fieldName string) int {
+	if tr.header != nil {
+		for _, field := range tr.header.fields {
+			if field.name == fieldName {
+				return int(field.length)
+			}
 		}
+	}
+
+	return dbfTextFieldLength
+}
+
+// textJoiner joins parts of text field value which are stored in multiple rows.
+// Only exact duplicates of already included parts are skipped, they appear when value is repeated
+// in continuation rows. Writer uses joiner for splitting values in the same way.
+type textJoiner struct {
+	field       string
+	width       int
+	text        string
+	parts       []string
+	lastPartLen int
+	diagnostics []Diagnostic
+	cfg         *readConfig
+}
+
+// add appends part of value from the row. Empty parts and duplicates are skipped.
+// Part which is not a duplicate but is included in text is appended and reported by diagnostic,
+// space which is inserted before part is reported too.
+func (j *textJoiner) add(val string, data rowData) {
+	if val == "" {
+		return
+	}
+	if j.isDuplicate(val) {
+		j.cfg.log(slog.LevelDebug, "duplicate part of text is skipped", "column", j.field, "index", data.index)
+		return
+	}
+
+	if strings.Contains(j.text, val) {
+		j.cfg.log(slog.LevelWarn, "part of text is repeated in value and it is appended",
+			"column", j.field, "index", data.index)
+		j.diagnostics = append(j.diagnostics, Diagnostic{
+			Kind:    DiagnosticRepeatedFragment,
+			Field:   j.field,
+			RowID:   data.rowID,
+			Message: fmt.Sprintf("part '%s' is repeated in value and it is appended", val),
+		})
+	}
+
+	leadingChar := ""
+	if j.needSeparator() {
+		leadingChar = " "
+		j.cfg.log(slog.LevelDebug, "space is inserted before part of text", "column", j.field, "index", data.index)
+		j.diagnostics = append(j.diagnostics, Diagnostic{
+			Kind:    DiagnosticSeparatorInserted,
+			Field:   j.field,
+			RowID:   data.rowID,
+			Message: fmt.Sprintf("space is inserted before part '%s', because previous part has length %d", val, j.lastPartLen),
+		})
+	}
+
+	j.text += leadingChar + val
+	j.parts = append(j.parts, val)
 
-		text += leadingChar + val
+	// Width of field is set in bytes, encodings of the file are single byte, so it is a number of runes.
+	j.lastPartLen = utf8.RuneCountInString(val)
+}
 
-		lastIncludedStrLen = len(val)
+// isDuplicate says whether part is equal to one of already included parts.
+func (j *textJoiner) isDuplicate(val string) bool {
+	for _, part := range j.parts {
+		if part == val {
+			return true
+		}
 	}
 
-	return text, nil
+	return false
+}
+
+// needSeparator says whether space will be added before the next part.
+// Field with value which ends with space is trimmed by one character less than width of the field,
+// so the space is restored by joiner.
+func (j *textJoiner) needSeparator() bool {
+	return j.lastPartLen == j.width-1
 }
 
 func getEnum(fieldName string) ([]string, error) {
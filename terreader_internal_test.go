@@ -3,6 +3,7 @@ package terreader
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -16,23 +17,22 @@ func Test_TerReader_setHelpData(t *testing.T) {
 		{"NUMBER": "1", "ROW_ID": "4"},
 		{"NUMBER": "1", "ROW_ID": "3"},
 	}
-	dbfTable := newDbfTable(rows)
-	tr := TerReader{dbfTable: dbfTable}
-	if err := tr.setHelpData(); err != nil {
+	tr := TerReader{table: NewMapTable(rows)}
+	if err := tr.setHelpData(nil); err != nil {
 		t.Fatal(err)
 	}
 
 	rowDataMap := map[uint64][]rowData{
 		1: {
-			{index: 0, rowID: 2},
 			{index: 4, rowID: 1},
-			{index: 5, rowID: 4},
+			{index: 0, rowID: 2},
 			{index: 6, rowID: 3},
+			{index: 5, rowID: 4},
 		},
 		2: {
 			{index: 1, rowID: 5},
-			{index: 2, rowID: 7},
 			{index: 3, rowID: 6},
+			{index: 2, rowID: 7},
 		},
 	}
 	for number, etalonSlice := range rowDataMap {
@@ -58,14 +58,14 @@ func Test_TerReader_setHelpData_WhenDataMapAlreadySet(t *testing.T) {
 		1: []rowData{},
 	}
 	tr := TerReader{rowDataMap: dataMap}
-	if err := tr.setHelpData(); err != nil {
+	if err := tr.setHelpData(nil); err != nil {
 		t.Fatal(err)
 	}
 }
 
 func Test_TerReader_buildRecord(t *testing.T) {
 	tr := TerReader{}
-	row, err := tr.buildRecord([]rowData{})
+	row, _, err := tr.buildRecord(&tr.config, []rowData{})
 	if row != nil {
 		t.Errorf("row not correct. Expected nil, got %+v", row)
 	}
@@ -76,6 +76,86 @@ func Test_TerReader_buildRecord(t *testing.T) {
 	}
 }
 
+func Test_TerReader_getTextValue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		header      *dbfHeader
+		parts       []string
+		text        string
+		diagnostics []Diagnostic
+	}{
+		{
+			name:  "when part is an exact duplicate",
+			parts: []string{"Olubunmi Pam", "Olubunmi Pam"},
+			text:  "Olubunmi Pam",
+		},
+		{
+			name:  "when part is repeated in text",
+			parts: []string{"Pam Olubunmi Pam", "Pam"},
+			text:  "Pam Olubunmi PamPam",
+			diagnostics: []Diagnostic{
+				{
+					Kind:    DiagnosticRepeatedFragment,
+					Field:   "NAMEU",
+					RowID:   2,
+					Message: "part 'Pam' is repeated in value and it is appended",
+				},
+			},
+		},
+		{
+			name:  "when multi-byte part fills field without trailing space",
+			parts: []string{strings.Repeat("Д", 253), "Е"},
+			text:  strings.Repeat("Д", 253) + " Е",
+			diagnostics: []Diagnostic{
+				{
+					Kind:    DiagnosticSeparatorInserted,
+					Field:   "NAMEU",
+					RowID:   2,
+					Message: "space is inserted before part 'Е', because previous part has length 253",
+				},
+			},
+		},
+		{
+			name:   "when width of field is set in header",
+			header: &dbfHeader{fields: []dbfField{{name: "NAMEU", length: 10}}},
+			parts:  []string{"Olubunmi,", "Pam"},
+			text:   "Olubunmi, Pam",
+			diagnostics: []Diagnostic{
+				{
+					Kind:    DiagnosticSeparatorInserted,
+					Field:   "NAMEU",
+					RowID:   2,
+					Message: "space is inserted before part 'Pam', because previous part has length 9",
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rows := make([]map[string]string, 0, len(testCase.parts))
+			rowDataSlice := make([]rowData, 0, len(testCase.parts))
+			for i, part := range testCase.parts {
+				rows = append(rows, map[string]string{"NAMEU": part})
+				rowDataSlice = append(rowDataSlice, rowData{index: i, rowID: uint64(i + 1)})
+			}
+
+			tr := TerReader{table: NewMapTable(rows), header: testCase.header}
+			text, diagnostics, err := tr.getTextValue(&tr.config, "NAMEU", rowDataSlice)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if text != testCase.text {
+				t.Errorf("get not correct string. Expected \"%s\", got \"%s\"", testCase.text, text)
+			}
+			if !reflect.DeepEqual(diagnostics, testCase.diagnostics) {
+				t.Errorf("diagnostics not correct. Expected %+v, got %+v", testCase.diagnostics, diagnostics)
+			}
+		})
+	}
+}
+
 func Test_TerReader_getEnumValue(t *testing.T) {
 	testCases := []struct {
 		fieldName    string
@@ -88,7 +168,7 @@ func Test_TerReader_getEnumValue(t *testing.T) {
 
 	tr := TerReader{}
 	for _, testCase := range testCases {
-		res, err := tr.getEnumValue(testCase.fieldName, testCase.rowDataSlice)
+		res, _, err := tr.getEnumValue(&tr.config, testCase.fieldName, testCase.rowDataSlice)
 
 		if testCase.err == nil && err != nil {
 			t.Fatal(err)
@@ -3,8 +3,11 @@ package terreader
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -46,7 +49,7 @@ func TestNewTerReader(t *testing.T) {
 		}
 
 		if testCase.reader == nil && reader != nil {
-			t.Errorf("get not correct Row. Expecter nil, got %+v", *reader)
+			t.Errorf("get not correct Row. Expecter nil, got %+v", reader)
 		}
 
 		if testCase.reader != nil && reader == nil {
@@ -105,6 +108,11 @@ func TestTerReader_WithContext(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	res := <-rowReadRes
+	if res.Error != context.Canceled || res.Row != nil {
+		t.Errorf("result not correct. Expected error %v, got %+v", context.Canceled, res)
+	}
+
 	if _, ok := (<-rowReadRes); ok {
 		t.Error("channel is still open")
 	}
@@ -116,13 +124,13 @@ func TestTerReader_AllowEmptyEnumValues(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if tr.allowEmptyEnumValues {
+	if tr.config.allowEmptyEnumValues {
 		t.Error("Option allowEmptyEnumValues has not correct value after struct initialize. Expected false, got true")
 	}
 
 	tr.AllowEmptyEnumValues()
 
-	if tr.allowEmptyEnumValues != true {
+	if tr.config.allowEmptyEnumValues != true {
 		t.Error("Option allowEmptyEnumValues has not correct value after call AllowEmptyEnumValues. Expected false, got true")
 	}
 }
@@ -130,8 +138,7 @@ func TestTerReader_AllowEmptyEnumValues(t *testing.T) {
 func TestTerReader_Read(t *testing.T) {
 	t.Run("when use mock dbf file data", func(t *testing.T) {
 		for _, testCase := range getTestCases() {
-			dbfTable := newDbfTable(testCase.rows)
-			tr := TerReader{dbfTable: dbfTable, ctx: context.Background()}
+			tr := TerReader{table: NewMapTable(testCase.rows), ctx: context.Background()}
 
 			rowReadRes, err := tr.Read(5)
 			if testCase.err == nil && err != nil {
@@ -199,7 +206,7 @@ func TestTerReader_Read(t *testing.T) {
 		Gr := time.Date(1988, time.September, 05, 0, 0, 0, 0, time.UTC)
 		CbDate := time.Date(2012, time.November, 10, 0, 0, 0, 0, time.UTC)
 		etalonRecords := []RowReadResult{
-			{&Row{Number: "1", Terror: "1", Tu: "3", Nameu: "Pharetra magna ac placerat", Descript: "Facilisi etiam dignissim diam quis enim lobortis. Suscipit adipiscing bibendum est ultricies integer quis auctor. At tempor commodo ullamcorper a lacus vestibulum sed arcu. Augue ut lectus arcu bibendum. Porttitor rhoncus dolor purus non enim praesent. Ac tincidunt vitae semper quis lectus nulla at volutpat diam.", Kodcr: "", Kodcn: "", Amr: "", Address: "", Kd: "03", Sd: "", Rg: "BN 5236025", Nd: "", Vd: "Et tortor consequat id porta.", Gr: &Gr, Yr: "1996", Mr: "", CbDate: &CbDate, CeDate: nil, Director: "", Founder: "", RowID: "1", Terrtype: ""}, 1, nil},
+			{Row: &Row{Number: "1", Terror: "1", Tu: "3", Nameu: "Pharetra magna ac placerat", Descript: "Facilisi etiam dignissim diam quis enim lobortis. Suscipit adipiscing bibendum est ultricies integer quis auctor. At tempor commodo ullamcorper a lacus vestibulum sed arcu. Augue ut lectus arcu bibendum. Porttitor rhoncus dolor purus non enim praesent. Ac tincidunt vitae semper quis lectus nulla at volutpat diam.", Kodcr: "", Kodcn: "", Amr: "", Address: "", Kd: "03", Sd: "", Rg: "BN 5236025", Nd: "", Vd: "Et tortor consequat id porta.", Gr: &Gr, Yr: "1996", Mr: "", CbDate: &CbDate, CeDate: nil, Director: "", Founder: "", RowID: "1", Terrtype: ""}, Number: 1},
 		}
 
 		num := 0
@@ -263,8 +270,7 @@ func TestTerReader_Read(t *testing.T) {
 			{"NUMBER": "1", "TERROR": "not_support", "TU": "1", "NAMEU": "", "DESCRIPT": "", "KODCR": "", "KODCN": "", "AMR": "", "ADRESS": "", "KD": "04", "SD": "", "RG": "", "ND": "", "VD": "", "GR": "", "YR": "", "MR": "", "CB_DATE": "", "CE_DATE": "", "DIRECTOR": "", "FOUNDER": "", "ROW_ID": "1", "TERRTYPE": ""},
 		}
 
-		dbfTable := newDbfTable(rows)
-		tr := TerReader{dbfTable: dbfTable, ctx: context.Background()}
+		tr := TerReader{table: NewMapTable(rows), ctx: context.Background()}
 		tr.AllowEmptyEnumValues()
 
 		rowReadRes, err := tr.Read(5)
@@ -408,11 +414,621 @@ func getSuccessEtalonRecords() []RowReadResult {
 	Gr9 := time.Date(2006, time.February, 23, 0, 0, 0, 0, time.UTC)
 
 	return []RowReadResult{
-		{&Row{Number: "1", Terror: "1", Tu: "3", Nameu: "Olubunmi Pam", Descript: "Diam quam nulla porttitor massa", Kodcr: "004-97", Kodcn: "8624", Amr: "4258 Queens Lane", Address: "2066 Confederate Drive", Kd: "01", Sd: "06458975", Rg: "632514", Nd: "718580865862", Vd: "knjoocgbbh", Gr: &Gr, Yr: "2008", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "1", Terrtype: "Resolution 1989"}, 1, nil},
-		{&Row{Number: "2", Terror: "1", Tu: "3", Nameu: "Neque sodales", Descript: "Quis risus sed vulputate odio", Kodcr: "005-66", Kodcn: "3256", Amr: "4258 Queens Lane", Address: "2066 Confederate Drive", Kd: "01", Sd: "06458975", Rg: "632514", Nd: "718580865862", Vd: "Interdum posuere", Gr: &Gr, Yr: "2005", Mr: "Elit pellentesque", CbDate: &CbDate2, CeDate: &CeDate2, Director: "Ac felis donec et odio", Founder: "Adipiscing enim", RowID: "3", Terrtype: "Sed risus pretium"}, 2, nil},
-		{&Row{Number: "3", Terror: "0", Tu: "2", Nameu: "Luctus accumsan", Descript: "", Kodcr: "004-55", Kodcn: "9632", Amr: "8855 venenatis", Address: "624 Venenatis", Kd: "04", Sd: "654684", Rg: "233044", Nd: "46761616", Vd: "pellentesque", Gr: nil, Yr: "2005", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "5", Terrtype: "Resolution 1959"}, 3, nil},
-		{&Row{Number: "4", Terror: "1", Tu: "1", Nameu: "Nulla facilisi nullam vehicula ipsum a arcu cursus. Elit eget gravida cum sociis natoque penatibus et magnis. Maecenas volutpat blandit aliquam etiam erat eta. Venenatis lectus magna fringilla urna porttitor rhoncus dolor purus. Fermentum posuere urna nec tincidunt praesent semper feugiat nibh.", Descript: "", Kodcr: "654-133", Kodcn: "5238", Amr: "Neque volutpat", Address: "", Kd: "01", Sd: "58692315", Rg: "865125", Nd: "", Vd: "", Gr: nil, Yr: "", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "5", Terrtype: ""}, 4, nil},
-		{&Row{Number: "5", Terror: "1", Tu: "1", Nameu: "In cursus turpis massa tincidunt dui ut ornare lectus sit. Vitae sapien pellentesque habitant morbi tristique senectus et netus et. Sagittis id consectetur purus ut. Vel pharetra vel turpisu nunc eget lorem dolor sed. Urna id volutpatar lacusan laoreet. Amet facilisis magna etiam tempor orci eu lobortis elementum nibh.", Descript: "", Kodcr: "052-752", Kodcn: "2580", Amr: "Neque volutpat", Address: "", Kd: "01", Sd: "3688", Rg: "548877", Nd: "", Vd: "", Gr: nil, Yr: "", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "7", Terrtype: ""}, 5, nil},
-		{&Row{Number: "6", Terror: "1", Tu: "1", Nameu: "Dolor sed viverra ipsum nunc", Descript: "Dolor purus non enim praesent. Et pharetra pharetra massa massa ultricies. Fermentum odio eu feugiat pretium. A diam maecenas sed enim ut sem viverra. Duis ut diam quam nulla porttitor massa id neque. Ac tortor dignissim convallis aenean et tortor at risus viverra. Viverra nibh cras pulvinar mattis nunc sed blandit.", Kodcr: "688-888", Kodcn: "6822", Amr: "", Address: "", Kd: "04", Sd: "", Rg: "", Nd: "464655", Vd: "", Gr: &Gr9, Yr: "", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "9", Terrtype: ""}, 6, nil},
+		{Row: &Row{Number: "1", Terror: "1", Tu: "3", Nameu: "Olubunmi Pam", Descript: "Diam quam nulla porttitor massa", Kodcr: "004-97", Kodcn: "8624", Amr: "4258 Queens Lane", Address: "2066 Confederate Drive", Kd: "01", Sd: "06458975", Rg: "632514", Nd: "718580865862", Vd: "knjoocgbbh", Gr: &Gr, Yr: "2008", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "1", Terrtype: "Resolution 1989"}, Number: 1},
+		{Row: &Row{Number: "2", Terror: "1", Tu: "3", Nameu: "Neque sodales", Descript: "Quis risus sed vulputate odio", Kodcr: "005-66", Kodcn: "3256", Amr: "4258 Queens Lane", Address: "2066 Confederate Drive", Kd: "01", Sd: "06458975", Rg: "632514", Nd: "718580865862", Vd: "Interdum posuere", Gr: &Gr, Yr: "2005", Mr: "Elit pellentesque", CbDate: &CbDate2, CeDate: &CeDate2, Director: "Ac felis donec et odio", Founder: "Adipiscing enim", RowID: "3", Terrtype: "Sed risus pretium"}, Number: 2},
+		{Row: &Row{Number: "3", Terror: "0", Tu: "2", Nameu: "Luctus accumsan", Descript: "", Kodcr: "004-55", Kodcn: "9632", Amr: "8855 venenatis", Address: "624 Venenatis", Kd: "04", Sd: "654684", Rg: "233044", Nd: "46761616", Vd: "pellentesque", Gr: nil, Yr: "2005", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "5", Terrtype: "Resolution 1959"}, Number: 3},
+		{Row: &Row{Number: "4", Terror: "1", Tu: "1", Nameu: "Nulla facilisi nullam vehicula ipsum a arcu cursus. Elit eget gravida cum sociis natoque penatibus et magnis. Maecenas volutpat blandit aliquam etiam erat eta. Venenatis lectus magna fringilla urna porttitor rhoncus dolor purus. Fermentum posuere urna nec tincidunt praesent semper feugiat nibh.", Descript: "", Kodcr: "654-133", Kodcn: "5238", Amr: "Neque volutpat", Address: "", Kd: "01", Sd: "58692315", Rg: "865125", Nd: "", Vd: "", Gr: nil, Yr: "", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "5", Terrtype: ""}, Number: 4},
+		{Row: &Row{Number: "5", Terror: "1", Tu: "1", Nameu: "In cursus turpis massa tincidunt dui ut ornare lectus sit. Vitae sapien pellentesque habitant morbi tristique senectus et netus et. Sagittis id consectetur purus ut. Vel pharetra vel turpisu nunc eget lorem dolor sed. Urna id volutpatar lacusan laoreet. Amet facilisis magna etiam tempor orci eu lobortis elementum nibh.", Descript: "", Kodcr: "052-752", Kodcn: "2580", Amr: "Neque volutpat", Address: "", Kd: "01", Sd: "3688", Rg: "548877", Nd: "", Vd: "", Gr: nil, Yr: "", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "7", Terrtype: ""}, Number: 5},
+		{Row: &Row{Number: "6", Terror: "1", Tu: "1", Nameu: "Dolor sed viverra ipsum nunc", Descript: "Dolor purus non enim praesent. Et pharetra pharetra massa massa ultricies. Fermentum odio eu feugiat pretium. A diam maecenas sed enim ut sem viverra. Duis ut diam quam nulla porttitor massa id neque. Ac tortor dignissim convallis aenean et tortor at risus viverra. Viverra nibh cras pulvinar mattis nunc sed blandit.", Kodcr: "688-888", Kodcn: "6822", Amr: "", Address: "", Kd: "04", Sd: "", Rg: "", Nd: "464655", Vd: "", Gr: &Gr9, Yr: "", Mr: "", CbDate: nil, CeDate: nil, Director: "", Founder: "", RowID: "9", Terrtype: ""}, Number: 6},
+	}
+}
+
+type deletedRowsMapTable struct {
+	*MapTable
+	deleted map[int]bool
+}
+
+func (t deletedRowsMapTable) RowIsDeleted(row int) bool {
+	return t.deleted[row]
+}
+
+func TestTerReader_IncludeDeletedRows(t *testing.T) {
+	rows := []map[string]string{
+		{"NUMBER": "1", "TERROR": "1", "TU": "1", "NAMEU": "Stale alias", "DESCRIPT": "", "KODCR": "", "KODCN": "", "AMR": "", "ADRESS": "", "KD": "04", "SD": "", "RG": "", "ND": "", "VD": "", "GR": "", "YR": "", "MR": "", "CB_DATE": "", "CE_DATE": "", "DIRECTOR": "", "FOUNDER": "", "ROW_ID": "1", "TERRTYPE": ""},
+		{"NUMBER": "1", "TERROR": "1", "TU": "1", "NAMEU": "Actual name", "DESCRIPT": "", "KODCR": "", "KODCN": "", "AMR": "", "ADRESS": "", "KD": "04", "SD": "", "RG": "", "ND": "", "VD": "", "GR": "", "YR": "", "MR": "", "CB_DATE": "", "CE_DATE": "", "DIRECTOR": "", "FOUNDER": "", "ROW_ID": "2", "TERRTYPE": ""},
+		{"NUMBER": "2", "TERROR": "1", "TU": "1", "NAMEU": "Deleted record", "DESCRIPT": "", "KODCR": "", "KODCN": "", "AMR": "", "ADRESS": "", "KD": "04", "SD": "", "RG": "", "ND": "", "VD": "", "GR": "", "YR": "", "MR": "", "CB_DATE": "", "CE_DATE": "", "DIRECTOR": "", "FOUNDER": "", "ROW_ID": "3", "TERRTYPE": ""},
+	}
+
+	testCases := []struct {
+		title   string
+		include bool
+		names   []string
+		deleted []bool
+		skipped int
+	}{
+		{"when deleted rows are skipped", false, []string{"Actual name"}, []bool{false}, 2},
+		{"when deleted rows are included", true, []string{"Stale aliasActual name", "Deleted record"}, []bool{true, true}, 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			table := deletedRowsMapTable{MapTable: NewMapTable(rows), deleted: map[int]bool{0: true, 2: true}}
+			tr, err := NewTerReaderFromTable(table)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if testCase.include {
+				tr.IncludeDeletedRows()
+			}
+
+			results, err := tr.Read(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			var deleted []bool
+			for res := range results {
+				if res.Error != nil {
+					t.Fatal(res.Error)
+				}
+				names = append(names, res.Row.Nameu)
+				deleted = append(deleted, res.Deleted)
+			}
+
+			if !reflect.DeepEqual(names, testCase.names) {
+				t.Errorf("names not correct. Expected %v, got %v", testCase.names, names)
+			}
+			if !reflect.DeepEqual(deleted, testCase.deleted) {
+				t.Errorf("deleted flags not correct. Expected %v, got %v", testCase.deleted, deleted)
+			}
+			if tr.SkippedDeletedRows() != testCase.skipped {
+				t.Errorf("num of skipped rows not correct. Expected %d, got %d", testCase.skipped, tr.SkippedDeletedRows())
+			}
+		})
+	}
+
+	t.Run("when row is deleted in dbf file", func(t *testing.T) {
+		data := readTestFile(t)
+		header, err := parseDBFHeader(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		header.record(data, 1)[0] = '*'
+
+		tr, err := NewTerReaderFromByteSlice(data, fileEncoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tr.setHelpData(nil); err != nil {
+			t.Fatal(err)
+		}
+		if tr.SkippedDeletedRows() != 1 {
+			t.Errorf("num of skipped rows not correct. Expected 1, got %d", tr.SkippedDeletedRows())
+		}
+	})
+}
+
+func conflictingRows() []map[string]string {
+	return []map[string]string{
+		{"NUMBER": "1", "TERROR": "1", "TU": "1", "NAMEU": "Olubunmi Pam", "DESCRIPT": "", "KODCR": "004-97", "KODCN": "", "AMR": "", "ADRESS": "", "KD": "04", "SD": "", "RG": "", "ND": "", "VD": "", "GR": "", "YR": "", "MR": "", "CB_DATE": "", "CE_DATE": "", "DIRECTOR": "", "FOUNDER": "", "ROW_ID": "1", "TERRTYPE": ""},
+		{"NUMBER": "1", "TERROR": "1", "TU": "2", "NAMEU": "Olubunmi Pam", "DESCRIPT": "", "KODCR": "005-66", "KODCN": "", "AMR": "", "ADRESS": "", "KD": "04", "SD": "", "RG": "", "ND": "", "VD": "", "GR": "", "YR": "", "MR": "", "CB_DATE": "", "CE_DATE": "", "DIRECTOR": "", "FOUNDER": "", "ROW_ID": "2", "TERRTYPE": ""},
+		{"NUMBER": "1", "TERROR": "1", "TU": "2", "NAMEU": "Olubunmi Pam", "DESCRIPT": "", "KODCR": "", "KODCN": "", "AMR": "", "ADRESS": "", "KD": "04", "SD": "", "RG": "", "ND": "", "VD": "", "GR": "", "YR": "", "MR": "", "CB_DATE": "", "CE_DATE": "", "DIRECTOR": "", "FOUNDER": "", "ROW_ID": "3", "TERRTYPE": ""},
+	}
+}
+
+func TestTerReader_Strict(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(conflictingRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := tr.Strict().Read(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := <-results
+	if res.Error != nil {
+		t.Fatal(res.Error)
+	}
+
+	etalon := []Diagnostic{
+		{Kind: DiagnosticConflict, Field: "TU", RowID: 2, Message: "value '2' is ignored, value '1' is used"},
+		{Kind: DiagnosticConflict, Field: "TU", RowID: 3, Message: "value '2' is ignored, value '1' is used"},
+		{Kind: DiagnosticConflict, Field: "KODCR", RowID: 2, Message: "value '005-66' is ignored, value '004-97' is used"},
+	}
+	if !reflect.DeepEqual(res.Diagnostics, etalon) {
+		t.Errorf("diagnostics not correct. Expected %+v, got %+v", etalon, res.Diagnostics)
+	}
+	if res.Row.Tu != "1" || res.Row.Kodcr != "004-97" {
+		t.Errorf("values not correct. Expected TU '1' and KODCR '004-97', got '%s' and '%s'", res.Row.Tu, res.Row.Kodcr)
+	}
+}
+
+func TestTerReader_WithConflictPolicy(t *testing.T) {
+	testCases := []struct {
+		title  string
+		policy ConflictPolicy
+		tu     string
+		kodcr  string
+		err    error
+	}{
+		{"when policy is first", ConflictFirst, "1", "004-97", nil},
+		{"when policy is last", ConflictLast, "2", "005-66", nil},
+		{"when policy is most common", ConflictMostCommon, "2", "004-97", nil},
+		{"when policy is error", ConflictError, "", "", errors.New("field 'TU' has conflicting values '1' and '2'")},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tr, err := NewTerReaderFromTable(NewMapTable(conflictingRows()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := tr.WithConflictPolicy(testCase.policy).Read(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res := <-results
+			if testCase.err != nil {
+				if res.Error == nil {
+					t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+				} else if res.Error.Error() != testCase.err.Error() {
+					t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), res.Error.Error())
+				}
+				return
+			}
+			if res.Error != nil {
+				t.Fatal(res.Error)
+			}
+
+			if res.Row.Tu != testCase.tu || res.Row.Kodcr != testCase.kodcr {
+				t.Errorf("values not correct. Expected TU '%s' and KODCR '%s', got '%s' and '%s'", testCase.tu, testCase.kodcr, res.Row.Tu, res.Row.Kodcr)
+			}
+			if res.Diagnostics != nil {
+				t.Errorf("diagnostics not correct. Expected nil, got %+v", res.Diagnostics)
+			}
+		})
+	}
+}
+
+// Static and date fields are taken from the first row even if it is empty, as before conflict policies were added.
+func TestTerReader_WithConflictPolicy_emptyFirstRow(t *testing.T) {
+	rows := conflictingRows()[:2]
+	rows[0]["KODCR"], rows[0]["GR"] = "", ""
+	rows[1]["KODCR"], rows[1]["GR"] = "RUS", "20000101"
+	gr := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		title       string
+		opts        []ReadOption
+		kodcr       string
+		gr          *time.Time
+		diagnostics []Diagnostic
+	}{
+		{"when policy is default", nil, "", nil, nil},
+		{
+			title: "when policy is default in strict mode",
+			opts:  []ReadOption{WithStrict()},
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticConflict, Field: "TU", RowID: 2, Message: "value '2' is ignored, value '1' is used"},
+				{Kind: DiagnosticConflict, Field: "KODCR", RowID: 2, Message: "value 'RUS' is ignored, value '' is used"},
+				{Kind: DiagnosticConflict, Field: "GR", RowID: 2, Message: "value '20000101' is ignored, value '' is used"},
+			},
+		},
+		{"when policy is last", []ReadOption{WithConflictPolicy(ConflictLast)}, "RUS", &gr, nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tr, err := NewTerReaderFromTable(NewMapTable(rows))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := tr.ReadContext(context.Background(), testCase.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res := <-results
+			if res.Error != nil {
+				t.Fatal(res.Error)
+			}
+			if res.Row.Kodcr != testCase.kodcr || !reflect.DeepEqual(res.Row.Gr, testCase.gr) {
+				t.Errorf("values not correct. Expected KODCR '%s' and GR %v, got '%s' and %v", testCase.kodcr, testCase.gr, res.Row.Kodcr, res.Row.Gr)
+			}
+			if !reflect.DeepEqual(res.Diagnostics, testCase.diagnostics) {
+				t.Errorf("diagnostics not correct. Expected %+v, got %+v", testCase.diagnostics, res.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestTerReader_enumDomains(t *testing.T) {
+	rows := []map[string]string{
+		{"NUMBER": "1", "TERROR": "1", "TU": "1", "NAMEU": "Olubunmi Pam", "DESCRIPT": "", "KODCR": "", "KODCN": "", "AMR": "", "ADRESS": "", "KD": "05", "SD": "", "RG": "", "ND": "", "VD": "", "GR": "", "YR": "", "MR": "", "CB_DATE": "", "CE_DATE": "", "DIRECTOR": "", "FOUNDER": "", "ROW_ID": "1", "TERRTYPE": ""},
+	}
+
+	testCases := []struct {
+		title       string
+		setup       func(tr *TerReader)
+		kd          string
+		diagnostics []Diagnostic
+		err         error
+	}{
+		{"when value is unknown", func(tr *TerReader) {}, "", nil, errors.New("can not find a suitable value for 'KD'")},
+		{"when enum is extended", func(tr *TerReader) { tr.ExtendEnum("KD", "05") }, "05", nil, nil},
+		{
+			"when enum is registered without value",
+			func(tr *TerReader) { tr.ExtendEnum("KD", "05").RegisterEnum("KD", "01") },
+			"",
+			nil,
+			errors.New("can not find a suitable value for 'KD'"),
+		},
+		{
+			"when unknown values are allowed",
+			func(tr *TerReader) { tr.AllowUnknownEnumValues() },
+			"05",
+			[]Diagnostic{{Kind: DiagnosticUnknownEnumValue, Field: "KD", RowID: 1, Message: "value '05' is not allowed"}},
+			nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tr, err := NewTerReaderFromTable(NewMapTable(rows))
+			if err != nil {
+				t.Fatal(err)
+			}
+			testCase.setup(tr)
+
+			results, err := tr.Read(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res := <-results
+			if testCase.err != nil {
+				if res.Error == nil {
+					t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+				} else if res.Error.Error() != testCase.err.Error() {
+					t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), res.Error.Error())
+				}
+				return
+			}
+			if res.Error != nil {
+				t.Fatal(res.Error)
+			}
+
+			if res.Row.Kd != testCase.kd {
+				t.Errorf("KD not correct. Expected '%s', got '%s'", testCase.kd, res.Row.Kd)
+			}
+			if !reflect.DeepEqual(res.Diagnostics, testCase.diagnostics) {
+				t.Errorf("diagnostics not correct. Expected %+v, got %+v", testCase.diagnostics, res.Diagnostics)
+			}
+		})
+	}
+}
+
+// nulPaddedTestFile returns test file data where spaces of records area are replaced by NUL bytes.
+func nulPaddedTestFile(t *testing.T) []byte {
+	data := readTestFile(t)
+
+	header, err := parseDBFHeader(data)
+	if err != nil {
+		t.Fatal(err)
 	}
+	records := data[header.headerLength : len(data)-1]
+	for i := range records {
+		if records[i] == ' ' {
+			records[i] = 0
+		}
+	}
+
+	return data
+}
+
+func TestTerReader_ConcurrentRead(t *testing.T) {
+	tr, err := NewTerReader(filePath, fileEncoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	etalon, err := tr.Read(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var etalonRows []*Row
+	for res := range etalon {
+		if res.Error != nil {
+			t.Fatal(res.Error)
+		}
+		etalonRows = append(etalonRows, res.Row)
+	}
+
+	testCases := []struct {
+		title string
+		data  []byte
+	}{
+		{"when values are padded by spaces", readTestFile(t)},
+		{"when values are padded by NUL bytes", nulPaddedTestFile(t)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tr, err := NewTerReaderFromByteSlice(testCase.data, fileEncoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			const readersNum = 16
+			var wg sync.WaitGroup
+			errs := make(chan error, readersNum)
+			for i := 0; i < readersNum; i++ {
+				wg.Add(1)
+				go func(chanBuff uint) {
+					defer wg.Done()
+
+					results, err := tr.Read(chanBuff)
+					if err != nil {
+						errs <- err
+						return
+					}
+
+					var rows []*Row
+					for res := range results {
+						if res.Error != nil {
+							errs <- res.Error
+							return
+						}
+						rows = append(rows, res.Row)
+					}
+					if !reflect.DeepEqual(rows, etalonRows) {
+						errs <- fmt.Errorf("rows not correct. Expected %+v, got %+v", etalonRows, rows)
+					}
+				}(uint(i % 2))
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestTerReader_ConcurrentReadWithContext(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled, err := tr.WithContext(ctx).Read(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := tr.WithContext(context.Background()).Read(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var canceledErrs []error
+	var resultsNum int
+	for res := range canceled {
+		canceledErrs = append(canceledErrs, res.Error)
+	}
+	for range results {
+		resultsNum++
+	}
+
+	if !reflect.DeepEqual(canceledErrs, []error{context.Canceled}) {
+		t.Errorf("results for canceled context not correct. Expected %v, got %v", []error{context.Canceled}, canceledErrs)
+	}
+	if resultsNum != 6 {
+		t.Errorf("num of results not correct. Expected 6, got %d", resultsNum)
+	}
+}
+
+func TestTerReader_WithContextConcurrently(t *testing.T) {
+	tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			results, err := tr.WithContext(context.Background()).Read(0)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for range results {
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTerReader_ReadContext_WhenBufferIsFull(t *testing.T) {
+	testCases := []struct {
+		title  string
+		stop   func(tr *TerReader, cancel context.CancelFunc)
+		etalon error
+	}{
+		{"when context is canceled", func(_ *TerReader, cancel context.CancelFunc) { cancel() }, context.Canceled},
+		{"when reader is closed", func(tr *TerReader, _ context.CancelFunc) { tr.Close() }, ErrReaderClosed},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			finished := make(chan struct{})
+			hooks := Hooks{ReadFinished: func(int, error) { close(finished) }}
+
+			results, err := tr.ReadContext(ctx, WithBuffer(1), WithHooks(hooks))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res := <-results; res.Error != nil || res.Number != 1 {
+				t.Fatalf("result not correct. Expected record 1, got %+v", res)
+			}
+
+			deadline := time.Now().Add(5 * time.Second)
+			// The last slot is reserved for the error, so buffer is full when records take the rest.
+			for len(results) < cap(results)-1 {
+				if time.Now().After(deadline) {
+					t.Fatal("buffer is not filled")
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			testCase.stop(tr, cancel)
+			<-finished
+
+			var last RowReadResult
+			next := uint64(2)
+			for res := range results {
+				if res.Error == nil {
+					if res.Number != next {
+						t.Errorf("number not correct. Expected %d, got %d", next, res.Number)
+					}
+					next++
+				}
+				last = res
+			}
+			if last.Error != testCase.etalon {
+				t.Errorf("error object not correct. Expected %v, got %v", testCase.etalon, last.Error)
+			}
+		})
+	}
+}
+
+func TestTerReader_ReadContext_WhenConsumerReadsWhileCanceling(t *testing.T) {
+	const recordsNum = 1000
+	rows := make([]map[string]string, 0, recordsNum)
+	for i := 1; i <= recordsNum; i++ {
+		row := getSuccessTestRows()[2]
+		row["NUMBER"] = strconv.Itoa(i)
+		row["ROW_ID"] = strconv.Itoa(i)
+		rows = append(rows, row)
+	}
+
+	tr, err := NewTerReaderFromTable(NewMapTable(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chanBuff := range []uint{0, 1, 16} {
+		ctx, cancel := context.WithCancel(context.Background())
+		results, err := tr.ReadContext(ctx, WithBuffer(chanBuff))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var last RowReadResult
+		next := uint64(1)
+		for res := range results {
+			if res.Error == nil {
+				if res.Number != next {
+					t.Errorf("number not correct for buffer %d. Expected %d, got %d", chanBuff, next, res.Number)
+				}
+				next++
+			}
+			if res.Number == 100 {
+				go cancel()
+			}
+			last = res
+		}
+		cancel()
+
+		if last.Error != context.Canceled {
+			t.Errorf("error object not correct for buffer %d. Expected %v, got %v", chanBuff, context.Canceled, last.Error)
+		}
+	}
+}
+
+func TestTerReader_Close(t *testing.T) {
+	t.Run("when read is not finished", func(t *testing.T) {
+		tr, err := NewTerReaderFromTable(NewMapTable(getSuccessTestRows()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := tr.Read(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res := <-results; res.Error != nil {
+			t.Fatal(res.Error)
+		}
+
+		// Consumer stops reading, Close must not wait for it.
+		closed := make(chan error)
+		go func() { closed <- tr.Close() }()
+		select {
+		case err := <-closed:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Close is blocked by read")
+		}
+
+		for res := range results {
+			if res.Error != nil && res.Error != ErrReaderClosed {
+				t.Errorf("error object not correct. Expected %v, got %v", ErrReaderClosed, res.Error)
+			}
+		}
+	})
+
+	t.Run("when reader is closed", func(t *testing.T) {
+		table := &closerMapTable{MapTable: NewMapTable(getSuccessTestRows())}
+		tr, err := NewTerReaderFromTable(table)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := tr.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := tr.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if table.closeCalls != 1 {
+			t.Errorf("num of table Close calls not correct. Expected 1, got %d", table.closeCalls)
+		}
+
+		if _, err := tr.Read(0); err != ErrReaderClosed {
+			t.Errorf("error object not correct. Expected %v, got %v", ErrReaderClosed, err)
+		}
+	})
+}
+
+type closerMapTable struct {
+	*MapTable
+	closeCalls int
+}
+
+func (t *closerMapTable) Close() error {
+	t.closeCalls++
+
+	return nil
 }
@@ -0,0 +1,144 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing provides OpenTelemetry spans for opening and reading of terrorists database.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/will-evil/terreader"
+)
+
+const instrumentationName = "github.com/will-evil/terreader/tracing"
+
+// Names of spans and events.
+const (
+	SpanOpen         = "terreader.Open"
+	SpanIndex        = "terreader.setHelpData"
+	SpanRead         = "terreader.Read"
+	EventRecordError = "record error"
+)
+
+// Option configures Tracer.
+type Option func(t *Tracer)
+
+// WithTracerProvider sets provider of tracers. Global provider is used if it is not set.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(t *Tracer) {
+		t.provider = tp
+	}
+}
+
+// Tracer structure that creates spans for opening and reading of the file.
+type Tracer struct {
+	provider trace.TracerProvider
+	tracer   trace.Tracer
+}
+
+// New is a constructor for Tracer structure.
+func New(opts ...Option) *Tracer {
+	t := &Tracer{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.provider == nil {
+		t.provider = otel.GetTracerProvider()
+	}
+	t.tracer = t.provider.Tracer(instrumentationName)
+
+	return t
+}
+
+// Open opens the file by terreader.Open in span SpanOpen.
+func (t *Tracer) Open(ctx context.Context, filePath string, opts ...terreader.Option) (*terreader.TerReader, error) {
+	_, span := t.tracer.Start(ctx, SpanOpen, trace.WithAttributes(attribute.String("terreader.file", filePath)))
+	defer span.End()
+
+	tr, err := terreader.Open(filePath, opts...)
+	if err != nil {
+		setError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("terreader.encoding", tr.DetectedEncoding()))
+
+	return tr, nil
+}
+
+// ReadContext reads records by tr.ReadContext in span SpanRead. Span is ended when reading is finished.
+// Indexing of rows is traced by child span SpanIndex and errors of records are added to the span
+// as EventRecordError events.
+func (t *Tracer) ReadContext(ctx context.Context, tr *terreader.TerReader, opts ...terreader.ReadOption) (chan terreader.RowReadResult, error) {
+	ctx, span := t.tracer.Start(ctx, SpanRead)
+
+	hooks := terreader.Hooks{
+		IndexBuilt: func(rows int, duration time.Duration, err error) {
+			end := time.Now()
+			_, indexSpan := t.tracer.Start(ctx, SpanIndex,
+				trace.WithTimestamp(end.Add(-duration)),
+				trace.WithAttributes(attribute.Int("terreader.rows", rows)),
+			)
+			if err != nil {
+				setError(indexSpan, err)
+			}
+			indexSpan.End(trace.WithTimestamp(end))
+		},
+		RecordBuilt: func(number uint64, _ time.Duration, err error) {
+			if err == nil {
+				return
+			}
+
+			attrs := []attribute.KeyValue{
+				attribute.Int64("terreader.number", int64(number)),
+				attribute.String("exception.message", err.Error()),
+			}
+			var recordErr *terreader.RecordError
+			if errors.As(err, &recordErr) {
+				attrs = append(attrs,
+					attribute.String("terreader.column", recordErr.Field),
+					attribute.String("terreader.error_kind", string(recordErr.Kind)),
+				)
+			}
+			span.AddEvent(EventRecordError, trace.WithAttributes(attrs...))
+		},
+		ReadFinished: func(records int, err error) {
+			span.SetAttributes(attribute.Int("terreader.records", records))
+			if err != nil {
+				setError(span, err)
+			}
+			span.End()
+		},
+	}
+
+	results, err := tr.ReadContext(ctx, append(opts[:len(opts):len(opts)], terreader.WithHooks(hooks))...)
+	if err != nil {
+		setError(span, err)
+		span.End()
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func setError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
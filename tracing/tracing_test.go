@@ -0,0 +1,174 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/will-evil/terreader"
+)
+
+const filePath = "../test/data/testfile.dbf"
+
+func newTestTracer() (*Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	return New(WithTracerProvider(tp)), exporter
+}
+
+func spanByName(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("span '%s' not found in %+v", name, spans)
+
+	return tracetest.SpanStub{}
+}
+
+func attributeValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func TestTracer_Open(t *testing.T) {
+	tracer, exporter := newTestTracer()
+
+	tr, err := tracer.Open(context.Background(), filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Close()
+
+	span := spanByName(t, exporter.GetSpans(), SpanOpen)
+	if val, _ := attributeValue(span.Attributes, "terreader.encoding"); val.AsString() != terreader.EncodingCP866 {
+		t.Errorf("encoding attribute not correct. Expected \"%s\", got \"%s\"", terreader.EncodingCP866, val.AsString())
+	}
+
+	exporter.Reset()
+	if _, err := tracer.Open(context.Background(), "not_exists.dbf"); err == nil {
+		t.Fatal("error object not correct. Expected error, got nil")
+	}
+	span = spanByName(t, exporter.GetSpans(), SpanOpen)
+	if span.Status.Code != codes.Error {
+		t.Errorf("status of span not correct. Expected %v, got %v", codes.Error, span.Status.Code)
+	}
+}
+
+func TestTracer_ReadContext(t *testing.T) {
+	tracer, exporter := newTestTracer()
+
+	tr, err := terreader.Open(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Close()
+
+	results, err := tracer.ReadContext(context.Background(), tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for res := range results {
+		if res.Error != nil {
+			t.Fatal(res.Error)
+		}
+	}
+
+	spans := exporter.GetSpans()
+	readSpan := spanByName(t, spans, SpanRead)
+	indexSpan := spanByName(t, spans, SpanIndex)
+
+	if indexSpan.Parent.SpanID() != readSpan.SpanContext.SpanID() {
+		t.Errorf("parent of span '%s' not correct. Expected %s, got %s",
+			SpanIndex, readSpan.SpanContext.SpanID(), indexSpan.Parent.SpanID())
+	}
+	if val, _ := attributeValue(indexSpan.Attributes, "terreader.rows"); val.AsInt64() != 2 {
+		t.Errorf("rows attribute not correct. Expected 2, got %d", val.AsInt64())
+	}
+	if val, _ := attributeValue(readSpan.Attributes, "terreader.records"); val.AsInt64() != 1 {
+		t.Errorf("records attribute not correct. Expected 1, got %d", val.AsInt64())
+	}
+	if readSpan.Status.Code != codes.Unset {
+		t.Errorf("status of span not correct. Expected %v, got %v", codes.Unset, readSpan.Status.Code)
+	}
+}
+
+func TestTracer_ReadContext_recordError(t *testing.T) {
+	tracer, exporter := newTestTracer()
+
+	table := terreader.NewMapTable([]map[string]string{{"NUMBER": "7", "ROW_ID": "1"}})
+	tr, err := terreader.NewTerReaderFromTable(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := tracer.ReadContext(context.Background(), tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range results {
+	}
+
+	readSpan := spanByName(t, exporter.GetSpans(), SpanRead)
+	if readSpan.Status.Code != codes.Error {
+		t.Errorf("status of span not correct. Expected %v, got %v", codes.Error, readSpan.Status.Code)
+	}
+
+	var event *sdktrace.Event
+	for i := range readSpan.Events {
+		if readSpan.Events[i].Name == EventRecordError {
+			event = &readSpan.Events[i]
+		}
+	}
+	if event == nil {
+		t.Fatalf("event '%s' not found in %+v", EventRecordError, readSpan.Events)
+	}
+
+	etalon := map[attribute.Key]attribute.Value{
+		"terreader.number":     attribute.Int64Value(7),
+		"terreader.column":     attribute.StringValue("TERROR"),
+		"terreader.error_kind": attribute.StringValue(string(terreader.ErrorKindField)),
+	}
+	for key, etalonValue := range etalon {
+		val, ok := attributeValue(event.Attributes, key)
+		if !ok || val != etalonValue {
+			t.Errorf("attribute '%s' not correct. Expected %v, got %v", key, etalonValue.Emit(), val.Emit())
+		}
+	}
+}
+
+func TestTracer_ReadContext_closedReader(t *testing.T) {
+	tracer, exporter := newTestTracer()
+
+	tr, err := terreader.Open(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.Close()
+
+	if _, err := tracer.ReadContext(context.Background(), tr); err != terreader.ErrReaderClosed {
+		t.Errorf("error object not correct. Expected %v, got %v", terreader.ErrReaderClosed, err)
+	}
+
+	readSpan := spanByName(t, exporter.GetSpans(), SpanRead)
+	if readSpan.Status.Code != codes.Error {
+		t.Errorf("status of span not correct. Expected %v, got %v", codes.Error, readSpan.Status.Code)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if tracer := New(); tracer.provider == nil {
+		t.Error("provider not correct. Expected global provider, got nil")
+	}
+}
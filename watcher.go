@@ -0,0 +1,189 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultWatchInterval = time.Minute
+
+// Snapshot structure for store all records of the file. Snapshot is not changed after publishing,
+// so it can be used by any number of goroutines while Watcher publishes new ones.
+type Snapshot struct {
+	Rows     []Row
+	ModTime  time.Time
+	Size     int64
+	LoadedAt time.Time
+}
+
+// fileState structure for store info which is used for detecting changes of the file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher structure that provides functionality for monitoring dbf file and publishing its records.
+// Watcher polls the file and publishes new Snapshot only if the changed file was read completely without errors.
+type Watcher struct {
+	filePath  string
+	encoding  string
+	interval  time.Duration
+	onError   func(error)
+	snapshot  atomic.Value
+	reloadMu  sync.Mutex
+	lastState fileState
+}
+
+// NewWatcher is a constructor for Watcher structure. It reads the file and publishes the first Snapshot.
+func NewWatcher(filePath, encoding string) (*Watcher, error) {
+	w := &Watcher{filePath: filePath, encoding: encoding, interval: defaultWatchInterval}
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// WithInterval sets interval of checking the file for changes.
+func (w *Watcher) WithInterval(interval time.Duration) *Watcher {
+	w.interval = interval
+
+	return w
+}
+
+// OnError sets function which is called when changed file can not be read.
+func (w *Watcher) OnError(fn func(error)) *Watcher {
+	w.onError = fn
+
+	return w
+}
+
+// Snapshot returns the last published Snapshot.
+func (w *Watcher) Snapshot() *Snapshot {
+	return w.snapshot.Load().(*Snapshot)
+}
+
+// Run checks the file for changes with configured interval until ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.check(); err != nil && w.onError != nil {
+				w.onError(err)
+			}
+		}
+	}
+}
+
+// Reload reads the file and publishes new Snapshot regardless of changes of the file.
+func (w *Watcher) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	state, err := w.stat()
+	if err != nil {
+		return err
+	}
+
+	return w.load(state)
+}
+
+// check reloads the file if it was changed since the last attempt.
+func (w *Watcher) check() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	state, err := w.stat()
+	if err != nil {
+		return err
+	}
+	if state == w.lastState {
+		return nil
+	}
+
+	return w.load(state)
+}
+
+func (w *Watcher) stat() (fileState, error) {
+	info, err := os.Stat(w.filePath)
+	if err != nil {
+		return fileState{}, err
+	}
+
+	return fileState{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+func (w *Watcher) load(state fileState) error {
+	w.lastState = state
+
+	rows, err := readAllRows(w.filePath, w.encoding)
+	if err != nil {
+		return err
+	}
+
+	w.snapshot.Store(&Snapshot{
+		Rows:     rows,
+		ModTime:  state.modTime,
+		Size:     state.size,
+		LoadedAt: time.Now(),
+	})
+
+	return nil
+}
+
+func readAllRows(filePath, encoding string) (rows []Row, err error) {
+	// Broken file can make dbf library panic, such file must not stop the watcher.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not read file '%s': %v", filePath, r)
+		}
+	}()
+
+	tr, err := NewTerReader(filePath, encoding)
+	if err != nil {
+		return nil, err
+	}
+	defer tr.Close()
+
+	results, err := tr.Read(0)
+	if err != nil {
+		return nil, err
+	}
+
+	for res := range results {
+		if res.Error != nil {
+			return nil, fmt.Errorf("error for record with number '%d': %w", res.Number, res.Error)
+		}
+		rows = append(rows, *res.Row)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("file has no records")
+	}
+
+	return rows, nil
+}
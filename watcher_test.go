@@ -0,0 +1,131 @@
+package terreader
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func copyTestFile(t *testing.T, dst string, modTime time.Time) {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(dst, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(dst, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewWatcher(t *testing.T) {
+	t.Run("when file not exists", func(t *testing.T) {
+		w, err := NewWatcher("not/exists/file.dbf", fileEncoding)
+		etalonError := errors.New("stat not/exists/file.dbf: no such file or directory")
+		if err == nil || err.Error() != etalonError.Error() {
+			t.Errorf("error not correct. Expected \"%s\", got %v", etalonError, err)
+		}
+		if w != nil {
+			t.Errorf("get not correct Watcher. Expected nil, got %+v", w)
+		}
+	})
+
+	t.Run("when file is read", func(t *testing.T) {
+		w, err := NewWatcher(filePath, fileEncoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		snapshot := w.Snapshot()
+		if len(snapshot.Rows) != 1 || snapshot.Rows[0].Number != "1" {
+			t.Errorf("snapshot rows not correct. Got %+v", snapshot.Rows)
+		}
+	})
+}
+
+func TestWatcher_check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.dbf")
+	modTime := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	copyTestFile(t, path, modTime)
+
+	w, err := NewWatcher(path, fileEncoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := w.Snapshot()
+
+	if err := w.check(); err != nil {
+		t.Fatal(err)
+	}
+	if w.Snapshot() != first {
+		t.Error("snapshot replaced when file not changed")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.check(); err == nil {
+		t.Error("error object not correct. Expected error for broken file, got nil")
+	}
+	if w.Snapshot() != first {
+		t.Error("snapshot replaced by broken file")
+	}
+	if err := w.check(); err != nil {
+		t.Errorf("broken file read again when not changed: %v", err)
+	}
+
+	copyTestFile(t, path, modTime.Add(time.Hour))
+	if err := w.check(); err != nil {
+		t.Fatal(err)
+	}
+	second := w.Snapshot()
+	if second == first {
+		t.Fatal("snapshot not replaced when file changed")
+	}
+	if !second.ModTime.Equal(modTime.Add(time.Hour)) {
+		t.Errorf("snapshot mod time not correct. Expected %v, got %v", modTime.Add(time.Hour), second.ModTime)
+	}
+	if len(first.Rows) != 1 || first.Rows[0].Number != "1" {
+		t.Errorf("previous snapshot was changed. Got %+v", first.Rows)
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.dbf")
+	copyTestFile(t, path, time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC))
+
+	w, err := NewWatcher(path, fileEncoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 10)
+	w.WithInterval(time.Millisecond).OnError(func(err error) { errs <- err })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errs:
+		if !os.IsNotExist(err) {
+			t.Errorf("error not correct. Expected not exists error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("error handler not called")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("error object not correct. Expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axgle/mahonia"
+)
+
+const (
+	dbfSignature = 0x03
+	dbfEndOfFile = 0x1A
+)
+
+// writerFieldLengths stores length of fields in files created by Writer. Text fields have dbfTextFieldLength
+// and date fields have length 8, other fields are listed here.
+var writerFieldLengths = map[string]byte{
+	"NUMBER": 10,
+	"TERROR": 1,
+	"TU":     1,
+	"KODCR":  5,
+	"KODCN":  5,
+	"KD":     2,
+	"SD":     10,
+	"RG":     20,
+	"ND":     12,
+	"VD":     100,
+	"YR":     4,
+	"ROW_ID": 10,
+}
+
+// writerLanguageDrivers stores language driver byte of the header for supported encodings.
+var writerLanguageDrivers = map[string]byte{
+	EncodingCP866:       0x65,
+	EncodingWindows1251: 0xC9,
+}
+
+// Writer structure that provides functionality for writing records to dbf file
+// in the same layout as terrorists database file.
+// Long values of text fields are split into continuation rows, so reading of the written file
+// with TerReader returns the same records.
+type Writer struct {
+	w         io.Writer
+	encoding  string
+	encoder   mahonia.Encoder
+	decoder   mahonia.Decoder
+	fields    []writerField
+	records   [][]byte
+	nextRowID uint64
+	closed    bool
+}
+
+// NewWriter is a constructor for Writer structure. Encoding can be EncodingCP866 or EncodingWindows1251.
+// Data is written to w on Close.
+func NewWriter(w io.Writer, encoding string) (*Writer, error) {
+	if _, ok := writerLanguageDrivers[encoding]; !ok {
+		return nil, fmt.Errorf("encoding '%s' is not supported by writer", encoding)
+	}
+
+	return &Writer{
+		w:         w,
+		encoding:  encoding,
+		encoder:   mahonia.NewEncoder(encoding),
+		decoder:   mahonia.NewDecoder(encoding),
+		fields:    writerFields(),
+		nextRowID: 1,
+	}, nil
+}
+
+// extraDocuments returns other documents of the row which are written to continuation rows.
+func extraDocuments(row *Row) []Document {
+	main := Document{Kd: row.Kd, Sd: row.Sd, Nd: row.Nd, Vd: row.Vd}
+
+	var documents []Document
+	for _, doc := range row.OtherDocuments {
+		if doc != main && !doc.isEmpty() && !includesDocument(documents, doc) {
+			documents = append(documents, doc)
+		}
+	}
+
+	return documents
+}
+
+func isDocumentColumn(name string) bool {
+	for _, column := range documentColumns {
+		if column == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writerField structure for store descriptor of field in file and index of Row field with its value.
+type writerField struct {
+	dbfField
+	fieldIndex int
+}
+
+// writerFields returns descriptors of fields in order of Row fields.
+func writerFields() []writerField {
+	rowType := reflect.TypeOf(Row{})
+	fields := make([]writerField, 0, rowType.NumField())
+
+	// Deletion flag is stored before fields.
+	offset := 1
+	for i := 0; i < rowType.NumField(); i++ {
+		// Documents are stored in document fields of rows, they have no own field.
+		if rowType.Field(i).Tag.Get("tr_col") == "" {
+			continue
+		}
+		field := dbfField{name: rowType.Field(i).Tag.Get("tr_col"), fieldType: 'C', offset: offset}
+		switch rowType.Field(i).Tag.Get("tr_type") {
+		case "text":
+			field.length = dbfTextFieldLength
+		case "date":
+			field.fieldType = 'D'
+			field.length = uint8(len(dateFormat))
+		default:
+			field.length = writerFieldLengths[field.name]
+		}
+		if field.name == "TU" {
+			field.fieldType = 'N'
+		}
+
+		fields = append(fields, writerField{dbfField: field, fieldIndex: i})
+		offset += int(field.length)
+	}
+
+	return fields
+}
+
+// Write adds record to the file. Values of text fields which are longer than field are split into several rows.
+// Trailing spaces of text values are trimmed because they can not be stored in dbf file.
+// If RowID is empty, ROW_ID of the first row is assigned by writer, otherwise RowID must be a number.
+// ROW_ID of continuation rows follows ROW_ID of the first row. OtherDocuments are written to document fields
+// of continuation rows.
+func (wr *Writer) Write(row *Row) error {
+	if wr.closed {
+		return errors.New("writer is closed")
+	}
+	if row == nil {
+		return errors.New("row can not be nil")
+	}
+
+	rowID := wr.nextRowID
+	if row.RowID != "" {
+		var err error
+		if rowID, err = strconv.ParseUint(row.RowID, 10, 64); err != nil {
+			return fmt.Errorf("row id '%s' is not a number", row.RowID)
+		}
+	}
+
+	val := reflect.ValueOf(row).Elem()
+	values := make([][]string, len(wr.fields))
+	rowsNum := 1
+
+	for i, field := range wr.fields {
+		var err error
+		switch val.Type().Field(field.fieldIndex).Tag.Get("tr_type") {
+		case "text":
+			values[i], err = splitText(strings.TrimRight(val.Field(field.fieldIndex).String(), " "), dbfTextFieldLength)
+		case "date":
+			var date string
+			if t := val.Field(field.fieldIndex).Interface().(*time.Time); t != nil {
+				date = t.Format(dateFormat)
+			}
+			values[i] = []string{date}
+		default:
+			values[i] = []string{val.Field(field.fieldIndex).String()}
+		}
+		if err != nil {
+			return fmt.Errorf("can not write field '%s' of record '%s': %w", field.name, row.Number, err)
+		}
+
+		if len(values[i]) > rowsNum {
+			rowsNum = len(values[i])
+		}
+	}
+
+	extraDocuments := extraDocuments(row)
+	if len(extraDocuments)+1 > rowsNum {
+		rowsNum = len(extraDocuments) + 1
+	}
+
+	records := make([][]byte, 0, rowsNum)
+	for r := 0; r < rowsNum; r++ {
+		record := bytes.Repeat([]byte{' '}, wr.recordLength())
+
+		for i, field := range wr.fields {
+			var value string
+			switch {
+			case field.name == "ROW_ID":
+				value = strconv.FormatUint(rowID+uint64(r), 10)
+			case val.Type().Field(field.fieldIndex).Tag.Get("tr_type") == "text":
+				if r < len(values[i]) {
+					value = values[i][r]
+				}
+			case r > 0 && r <= len(extraDocuments) && isDocumentColumn(field.name):
+				value = extraDocuments[r-1].value(field.name)
+			default:
+				// Static, enum and date values are repeated in every row of record.
+				value = values[i][0]
+			}
+
+			encoded, err := wr.encode(value)
+			if err != nil {
+				return fmt.Errorf("can not write field '%s' of record '%s': %w", field.name, row.Number, err)
+			}
+			if len(encoded) > int(field.length) {
+				return fmt.Errorf("value '%s' of field '%s' is longer than %d", value, field.name, field.length)
+			}
+			copy(record[field.offset:], encoded)
+		}
+
+		records = append(records, record)
+	}
+
+	wr.records = append(wr.records, records...)
+	if next := rowID + uint64(rowsNum); next > wr.nextRowID {
+		wr.nextRowID = next
+	}
+
+	return nil
+}
+
+// encode converts value to encoding of the file. Error is returned if value has characters
+// which are absent in the encoding.
+func (wr *Writer) encode(value string) ([]byte, error) {
+	encoded := wr.encoder.ConvertString(value)
+	if wr.decoder.ConvertString(encoded) != value {
+		return nil, fmt.Errorf("value '%s' can not be encoded in '%s'", value, wr.encoding)
+	}
+
+	return []byte(encoded), nil
+}
+
+// recordLength returns length of record including deletion flag.
+func (wr *Writer) recordLength() int {
+	last := wr.fields[len(wr.fields)-1]
+
+	return last.offset + int(last.length)
+}
+
+// Close writes header and all records to the writer. Writer can not be used after Close.
+func (wr *Writer) Close() error {
+	if wr.closed {
+		return errors.New("writer is closed")
+	}
+	wr.closed = true
+
+	headerLength := dbfHeaderSize + len(wr.fields)*dbfFieldDescriptorSize + 1
+	recordLength := wr.recordLength()
+
+	buf := bytes.NewBuffer(make([]byte, 0, headerLength+len(wr.records)*recordLength+1))
+
+	now := time.Now()
+	header := make([]byte, dbfHeaderSize)
+	header[0] = dbfSignature
+	header[1] = byte(now.Year() - 1900)
+	header[2] = byte(now.Month())
+	header[3] = byte(now.Day())
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(wr.records)))
+	binary.LittleEndian.PutUint16(header[8:10], uint16(headerLength))
+	binary.LittleEndian.PutUint16(header[10:12], uint16(recordLength))
+	header[dbfLanguageDriverPos] = writerLanguageDrivers[wr.encoding]
+	buf.Write(header)
+
+	for _, field := range wr.fields {
+		descriptor := make([]byte, dbfFieldDescriptorSize)
+		copy(descriptor, field.name)
+		descriptor[11] = field.fieldType
+		descriptor[16] = field.length
+		descriptor[17] = field.decimals
+		buf.Write(descriptor)
+	}
+	buf.WriteByte(dbfFieldTerminator)
+
+	for _, record := range wr.records {
+		buf.Write(record)
+	}
+	buf.WriteByte(dbfEndOfFile)
+
+	_, err := buf.WriteTo(wr.w)
+
+	return err
+}
+
+// splitText splits value into parts which are stored in continuation rows.
+// Parts are chosen so that joining of them by textJoiner returns the value:
+// part can not end with space which is trimmed in dbf file and can not be equal to already included part,
+// and if joiner adds separator after part, the value must have space in this place.
+// Longer parts are tried first, positions where value can not be split are remembered.
+// Remembered positions do not take into account previous parts, so value with many repeated parts
+// can be reported as not splittable.
+func splitText(value string, length int) ([]string, error) {
+	runes := []rune(value)
+	failed := make(map[textSplitState]bool)
+
+	var split func(joiner textJoiner, pos int) []string
+	split = func(joiner textJoiner, pos int) []string {
+		if pos == len(runes) {
+			return []string{}
+		}
+
+		state := textSplitState{pos: pos, separator: joiner.needSeparator()}
+		if failed[state] {
+			return nil
+		}
+
+		k := length
+		if k > len(runes)-pos {
+			k = len(runes) - pos
+		}
+		for ; k > 0; k-- {
+			part := string(runes[pos : pos+k])
+			if runes[pos+k-1] == ' ' || joiner.isDuplicate(part) {
+				continue
+			}
+
+			next := joiner
+			next.parts = next.parts[:len(next.parts):len(next.parts)]
+			next.add(part, rowData{})
+			nextPos := pos + k
+			if nextPos < len(runes) && next.needSeparator() {
+				if runes[nextPos] != ' ' {
+					continue
+				}
+				nextPos++
+			}
+
+			if parts := split(next, nextPos); parts != nil {
+				return append([]string{part}, parts...)
+			}
+		}
+
+		failed[state] = true
+
+		return nil
+	}
+
+	parts := split(textJoiner{width: length}, 0)
+	if parts == nil {
+		return nil, fmt.Errorf("value '%s' can not be split into rows", value)
+	}
+
+	return parts, nil
+}
+
+// textSplitState structure for store position in value which is split by splitText.
+type textSplitState struct {
+	pos       int
+	separator bool
+}
@@ -0,0 +1,182 @@
+package terreader
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readWrittenRows(t *testing.T, data []byte, encoding string) []*Row {
+	reader, err := NewTerReaderFromByteSlice(data, encoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := reader.Read(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []*Row
+	for res := range results {
+		if res.Error != nil {
+			t.Fatal(res.Error)
+		}
+		rows = append(rows, res.Row)
+	}
+
+	return rows
+}
+
+// numberedWords returns text of words with numbers. Text has no repeated parts,
+// so it can be split into rows.
+func numberedWords(word string, n int) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = word + strconv.Itoa(i)
+	}
+
+	return strings.Join(words, " ")
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	birthDate := time.Date(1980, 5, 17, 0, 0, 0, 0, time.UTC)
+	etalon := []*Row{
+		{
+			Number:   "1",
+			Terror:   "1",
+			Tu:       "1",
+			Nameu:    "ИВАНОВ ИВАН ИВАНОВИЧ",
+			Descript: numberedWords("ОПИСАНИЕ", 60),
+			Kodcr:    "643",
+			Kodcn:    "643",
+			Amr:      "Г. МОСКВА",
+			Address:  strings.Repeat("a", 252) + " " + strings.Repeat("b", 300),
+			Kd:       "01",
+			Sd:       "4500",
+			Rg:       "",
+			Nd:       "123456",
+			Vd:       "ОВД",
+			Gr:       &birthDate,
+			Yr:       "1980",
+			Mr:       "Г. МОСКВА",
+			RowID:    "1",
+			Terrtype: "ЛИЦО",
+			OtherDocuments: []Document{
+				{Kd: "03", Nd: "987654"},
+				{Kd: "01", Sd: "4501", Nd: "654321", Vd: "УФМС"},
+			},
+		},
+		{
+			Number:   "2",
+			Terror:   "0",
+			Tu:       "2",
+			Nameu:    strings.Repeat("x", 253) + " " + strings.Repeat("y", 10),
+			Descript: "",
+			Kd:       "0",
+			Director: strings.Repeat("Д", 254) + strings.Repeat("Е", 254) + "Ж",
+			RowID:    "100",
+		},
+	}
+
+	for _, encoding := range []string{EncodingCP866, EncodingWindows1251} {
+		t.Run(encoding, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range etalon {
+				if err := w.Write(row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			rows := readWrittenRows(t, buf.Bytes(), EncodingAuto)
+			if !reflect.DeepEqual(rows, etalon) {
+				t.Errorf("rows not correct. Expected %+v, got %+v", etalon, rows)
+			}
+		})
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	t.Run("when row id is empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, EncodingCP866)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := []*Row{
+			{Number: "1", Terror: "1", Tu: "1", Kd: "0", Nameu: strings.Repeat("n", 300)},
+			{Number: "2", Terror: "1", Tu: "1", Kd: "0"},
+		}
+		for _, row := range rows {
+			if err := w.Write(row); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		result := readWrittenRows(t, buf.Bytes(), EncodingCP866)
+		if result[0].RowID != "1" || result[1].RowID != "3" {
+			t.Errorf("row ids not correct. Expected 1 and 3, got %s and %s", result[0].RowID, result[1].RowID)
+		}
+	})
+
+	testCases := []struct {
+		row *Row
+		err error
+	}{
+		{nil, errors.New("row can not be nil")},
+		{&Row{Number: "1", RowID: "first"}, errors.New("row id 'first' is not a number")},
+		{
+			&Row{Number: "12345678901"},
+			errors.New("value '12345678901' of field 'NUMBER' is longer than 10"),
+		},
+		{
+			&Row{Number: "1", Nameu: "名前"},
+			errors.New("can not write field 'NAMEU' of record '1': value '名前' can not be encoded in '866'"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		w, err := NewWriter(&bytes.Buffer{}, EncodingCP866)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = w.Write(testCase.row)
+		if err == nil {
+			t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+		} else if err.Error() != testCase.err.Error() {
+			t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), err.Error())
+		}
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	_, err := NewWriter(&bytes.Buffer{}, "utf-8")
+	etalon := errors.New("encoding 'utf-8' is not supported by writer")
+	if err == nil || err.Error() != etalon.Error() {
+		t.Errorf("error object not correct. Expected %v, got %v", etalon, err)
+	}
+}
+
+func TestWriterFields(t *testing.T) {
+	rowType := reflect.TypeOf(Row{})
+	for _, field := range writerFields() {
+		if col := rowType.Field(field.fieldIndex).Tag.Get("tr_col"); col != field.name {
+			t.Errorf("Row field of '%s' not correct, got field of '%s'", field.name, col)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright © 2021 Alexey Konovalenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terreader
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axgle/mahonia"
+)
+
+const (
+	xmlDateFormat = "2006-01-02"
+	// noDocumentKD is a value of KD column for subjects without documents.
+	noDocumentKD = "0"
+)
+
+// xmlList structure for store list in XML format of Rosfinmonitoring.
+type xmlList struct {
+	XMLName  xml.Name     `xml:"Перечень"`
+	Actual   []xmlSubject `xml:"АктуальныйПеречень>Субъект"`
+	Excluded []xmlSubject `xml:"ИсключенныеИзПеречня>Субъект"`
+}
+
+type xmlSubject struct {
+	ID           string        `xml:"ИдСубъекта"`
+	TypeID       string        `xml:"ТипСубъекта>Идентификатор"`
+	Terrorist    string        `xml:"Террорист"`
+	Person       *xmlPerson    `xml:"ФЛ"`
+	Organization *xmlOrg       `xml:"ЮЛ"`
+	Addresses    []string      `xml:"СписокАдресов>Адрес>Текст"`
+	IncludedAt   string        `xml:"ДатаВключения"`
+	ExcludedAt   string        `xml:"ДатаИсключения"`
+	Description  string        `xml:"Описание"`
+	Resolution   string        `xml:"Резолюция"`
+	Documents    []xmlDocument `xml:"СписокДокументов>Документ"`
+}
+
+type xmlPerson struct {
+	Name       string   `xml:"ФИО"`
+	Aliases    []string `xml:"СписокДрНаименований>ДрНаименование>ФИО"`
+	BirthDate  string   `xml:"ДатаРождения"`
+	BirthYear  string   `xml:"ГодРождения"`
+	BirthPlace string   `xml:"МестоРождения"`
+}
+
+type xmlOrg struct {
+	Name     string   `xml:"Наименование"`
+	Aliases  []string `xml:"СписокДрНаименований>ДрНаименование>Наименование"`
+	RegNum   string   `xml:"ОГРН"`
+	Director string   `xml:"Руководитель"`
+	Founder  string   `xml:"Учредитель"`
+}
+
+type xmlDocument struct {
+	Type     string `xml:"ТипДокумента"`
+	Series   string `xml:"Серия"`
+	Number   string `xml:"Номер"`
+	IssuedBy string `xml:"ОрганВыдачи"`
+}
+
+// NewXMLTable is a constructor for Table which reads list in XML format of Rosfinmonitoring.
+// Every subject of the list becomes one row with columns of the dbf file, so TerReader returns the same records
+// for XML and dbf files. Aliases are appended to NAMEU in brackets, addresses are joined to ADRESS
+// and the first document fills KD, SD, ND and VD columns (KD is "0" for subjects without documents).
+// Other documents are stored in continuation rows with the same NUMBER, like in dbf file, so they are
+// returned in OtherDocuments of the record.
+// Encoding declared in XML header is supported if it is known to mahonia package, for example windows-1251.
+func NewXMLTable(r io.Reader) (*MapTable, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		d := mahonia.NewDecoder(charset)
+		if d == nil {
+			return nil, fmt.Errorf("not support charset '%s'", charset)
+		}
+		return d.NewReader(input), nil
+	}
+
+	var list xmlList
+	if err := decoder.Decode(&list); err != nil {
+		return nil, err
+	}
+
+	subjects := append(list.Actual, list.Excluded...)
+	rows := make([]map[string]string, 0, len(subjects))
+	for _, subject := range subjects {
+		subjectRows, err := subject.toRows(len(rows) + 1)
+		if err != nil {
+			return nil, fmt.Errorf("subject '%s': %w", subject.ID, err)
+		}
+		rows = append(rows, subjectRows...)
+	}
+
+	return NewMapTable(rows), nil
+}
+
+// NewXMLTableFromFile is a Table constructor for file with list in XML format.
+func NewXMLTableFromFile(filePath string) (*MapTable, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewXMLTable(f)
+}
+
+// NewTerReaderFromXML is a TerReader constructor for file with list in XML format.
+func NewTerReaderFromXML(filePath string) (*TerReader, error) {
+	table, err := NewXMLTableFromFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTerReaderFromTable(table)
+}
+
+// toRows returns row of the subject and continuation rows for documents except the first one.
+// ROW_ID of rows starts from provided one.
+func (s xmlSubject) toRows(rowID int) ([]map[string]string, error) {
+	row, err := s.toRow(rowID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []map[string]string{row}
+	for i := 1; i < len(s.Documents); i++ {
+		docRow := make(map[string]string, len(row))
+		for column := range row {
+			docRow[column] = ""
+		}
+		docRow["NUMBER"] = row["NUMBER"]
+		docRow["ROW_ID"] = strconv.Itoa(rowID + i)
+		s.Documents[i].fill(docRow)
+		rows = append(rows, docRow)
+	}
+
+	return rows, nil
+}
+
+func (s xmlSubject) toRow(rowID int) (map[string]string, error) {
+	if _, err := strconv.ParseUint(strings.TrimSpace(s.ID), 10, 64); err != nil {
+		return nil, err
+	}
+
+	row := map[string]string{
+		"NUMBER":   strings.TrimSpace(s.ID),
+		"ROW_ID":   strconv.Itoa(rowID),
+		"TERROR":   strings.TrimSpace(s.Terrorist),
+		"TU":       strings.TrimSpace(s.TypeID),
+		"NAMEU":    "",
+		"DESCRIPT": strings.TrimSpace(s.Description),
+		"KODCR":    "",
+		"KODCN":    "",
+		"AMR":      "",
+		"ADRESS":   joinNonEmpty(s.Addresses, "; "),
+		"KD":       noDocumentKD,
+		"SD":       "",
+		"RG":       "",
+		"ND":       "",
+		"VD":       "",
+		"GR":       "",
+		"YR":       "",
+		"MR":       "",
+		"CB_DATE":  "",
+		"CE_DATE":  "",
+		"DIRECTOR": "",
+		"FOUNDER":  "",
+		"TERRTYPE": strings.TrimSpace(s.Resolution),
+	}
+
+	switch {
+	case s.Person != nil:
+		row["NAMEU"] = nameWithAliases(s.Person.Name, s.Person.Aliases)
+		row["YR"] = strings.TrimSpace(s.Person.BirthYear)
+		row["MR"] = strings.TrimSpace(s.Person.BirthPlace)
+
+		birthDate, err := convertXMLDate(s.Person.BirthDate)
+		if err != nil {
+			return nil, err
+		}
+		row["GR"] = birthDate
+		if row["YR"] == "" && birthDate != "" {
+			row["YR"] = birthDate[:4]
+		}
+	case s.Organization != nil:
+		row["NAMEU"] = nameWithAliases(s.Organization.Name, s.Organization.Aliases)
+		row["RG"] = strings.TrimSpace(s.Organization.RegNum)
+		row["DIRECTOR"] = strings.TrimSpace(s.Organization.Director)
+		row["FOUNDER"] = strings.TrimSpace(s.Organization.Founder)
+	}
+
+	if len(s.Documents) > 0 {
+		s.Documents[0].fill(row)
+	}
+
+	var err error
+	if row["CB_DATE"], err = convertXMLDate(s.IncludedAt); err != nil {
+		return nil, err
+	}
+	if row["CE_DATE"], err = convertXMLDate(s.ExcludedAt); err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// fill sets document columns of the row.
+func (d xmlDocument) fill(row map[string]string) {
+	row["KD"] = strings.TrimSpace(d.Type)
+	row["SD"] = strings.TrimSpace(d.Series)
+	row["ND"] = strings.TrimSpace(d.Number)
+	row["VD"] = strings.TrimSpace(d.IssuedBy)
+}
+
+// convertXMLDate converts date from XML format to format of dbf file.
+func convertXMLDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	// Dates can be written with time part.
+	if len(value) > len(xmlDateFormat) {
+		value = value[:len(xmlDateFormat)]
+	}
+
+	t, err := time.Parse(xmlDateFormat, value)
+	if err != nil {
+		return "", err
+	}
+
+	return t.Format(dateFormat), nil
+}
+
+func nameWithAliases(name string, aliases []string) string {
+	name = strings.TrimSpace(name)
+	if joined := joinNonEmpty(aliases, "; "); joined != "" {
+		return name + " (" + joined + ")"
+	}
+
+	return name
+}
+
+func joinNonEmpty(values []string, sep string) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+
+	return strings.Join(parts, sep)
+}
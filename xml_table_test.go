@@ -0,0 +1,88 @@
+package terreader
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/axgle/mahonia"
+)
+
+const xmlFilePath = "./test/data/testfile.xml"
+
+func TestNewTerReaderFromXML(t *testing.T) {
+	tr, err := NewTerReaderFromXML(xmlFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := tr.Read(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []Row
+	for res := range results {
+		if res.Error != nil {
+			t.Fatal(res.Error)
+		}
+		rows = append(rows, *res.Row)
+	}
+
+	Gr := time.Date(1988, time.September, 05, 0, 0, 0, 0, time.UTC)
+	CbDate1 := time.Date(2012, time.November, 10, 0, 0, 0, 0, time.UTC)
+	CbDate2 := time.Date(2019, time.May, 01, 0, 0, 0, 0, time.UTC)
+	CeDate2 := time.Date(2020, time.January, 01, 0, 0, 0, 0, time.UTC)
+	etalonRows := []Row{
+		{Number: "1", Terror: "1", Tu: "3", Nameu: "ИВАНОВ ИВАН ИВАНОВИЧ (IVANOV IVAN; ИВАНОВ ИВАН)", Descript: "ОПИСАНИЕ", Address: "Г. ТВЕРЬ; Г. МОСКВА", Kd: "01", Sd: "45 06", Nd: "123456", Vd: "ОВД", Gr: &Gr, Yr: "1988", Mr: "Г. ТВЕРЬ", CbDate: &CbDate1, RowID: "2", Terrtype: "Resolution 1989", OtherDocuments: []Document{{Kd: "03", Nd: "987654"}}},
+		{Number: "2", Terror: "0", Tu: "1", Nameu: "ООО \"РОМАШКА\" (ROMASHKA LLC)", Address: "Г. МОСКВА, УЛ. ЛЕНИНА, Д. 1", Kd: "0", Rg: "1027700132195", CbDate: &CbDate2, CeDate: &CeDate2, Director: "ПЕТРОВ ПЕТР", RowID: "1"},
+	}
+
+	if !reflect.DeepEqual(rows, etalonRows) {
+		t.Errorf("get not correct rows. Expected %+v, got %+v", etalonRows, rows)
+	}
+}
+
+func TestNewXMLTable(t *testing.T) {
+	t.Run("when encoding is windows-1251", func(t *testing.T) {
+		data := `<?xml version="1.0" encoding="windows-1251"?>
+<Перечень><АктуальныйПеречень><Субъект><ИдСубъекта>7</ИдСубъекта><ФЛ><ФИО>ПЕТРОВ ПЁТР</ФИО></ФЛ></Субъект></АктуальныйПеречень></Перечень>`
+		encoded := mahonia.NewEncoder("windows-1251").ConvertString(data)
+
+		table, err := NewXMLTable(strings.NewReader(encoded))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		name, err := table.FieldValueByName(0, "NAMEU")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "ПЕТРОВ ПЁТР" {
+			t.Errorf("NAMEU not correct. Expected \"ПЕТРОВ ПЁТР\", got \"%s\"", name)
+		}
+	})
+
+	testCases := []struct {
+		data string
+		err  error
+	}{
+		{`<?xml version="1.0" encoding="koi8-unknown"?><Перечень/>`, errors.New("xml: opening charset \"koi8-unknown\": not support charset 'koi8-unknown'")},
+		{`<Перечень><АктуальныйПеречень><Субъект><ИдСубъекта>A1</ИдСубъекта></Субъект></АктуальныйПеречень></Перечень>`, errors.New("subject 'A1': strconv.ParseUint: parsing \"A1\": invalid syntax")},
+		{`<Перечень><АктуальныйПеречень><Субъект><ИдСубъекта>1</ИдСубъекта><ДатаВключения>01.01.2020</ДатаВключения></Субъект></АктуальныйПеречень></Перечень>`, errors.New("subject '1': parsing time \"01.01.2020\" as \"2006-01-02\": cannot parse \"01.01.2020\" as \"2006\"")},
+	}
+
+	for _, testCase := range testCases {
+		table, err := NewXMLTable(strings.NewReader(testCase.data))
+		if err == nil {
+			t.Errorf("error object not correct. Expected %v, got nil", testCase.err)
+		} else if err.Error() != testCase.err.Error() {
+			t.Errorf("error message not correct. Expected \"%s\", got \"%s\"", testCase.err.Error(), err.Error())
+		}
+		if table != nil {
+			t.Errorf("get not correct table. Expected nil, got %+v", table)
+		}
+	}
+}